@@ -0,0 +1,172 @@
+package ilcd
+
+// withLang returns the given multi-language string with the value for the
+// given language set to the given value.
+func withLang(ls LangString, lang, value string) LangString {
+	for i := range ls {
+		if ls[i].Lang == lang {
+			ls[i].Value = value
+			return ls
+		}
+	}
+	return append(ls, LangStringItem{Lang: lang, Value: value})
+}
+
+// FlowBuilder can be used to construct a new flow data set, e.g.:
+//
+//	flow := NewFlow(uuid).
+//		SetBaseName("en", "carbon dioxide").
+//		SetType(ElementaryFlow).
+//		AddFlowProperty(0, massRef, 1.0).
+//		SetReferenceProperty(0).
+//		Build()
+//	err := writer.WriteDataSet(flow)
+type FlowBuilder struct {
+	flow *Flow
+}
+
+// NewFlow creates a new builder for a flow with the given UUID.
+func NewFlow(uuid string) *FlowBuilder {
+	return &FlowBuilder{flow: &Flow{
+		Info: &FlowInfo{
+			UUID: uuid,
+			Name: &FlowName{},
+		},
+		Publication: &CommonPublication{},
+	}}
+}
+
+// SetBaseName sets the base name of the flow in the given language.
+func (b *FlowBuilder) SetBaseName(lang, name string) *FlowBuilder {
+	b.flow.Info.Name.BaseName = withLang(b.flow.Info.Name.BaseName, lang, name)
+	return b
+}
+
+// SetType sets the type of the flow.
+func (b *FlowBuilder) SetType(t FlowType) *FlowBuilder {
+	b.flow.Type = t.String()
+	return b
+}
+
+// SetVersion sets the version of the flow.
+func (b *FlowBuilder) SetVersion(version string) *FlowBuilder {
+	b.flow.Publication.Version = version
+	return b
+}
+
+// SetCAS sets the CAS number of the flow.
+func (b *FlowBuilder) SetCAS(cas string) *FlowBuilder {
+	b.flow.Info.CAS = cas
+	return b
+}
+
+// AddFlowProperty adds a flow property with the given internal ID, reference
+// to the flow property data set, and conversion factor (mean value) to the
+// flow.
+func (b *FlowBuilder) AddFlowProperty(id int, ref *Ref, mean float64) *FlowBuilder {
+	b.flow.FlowProperties = append(b.flow.FlowProperties, FlowPropertyRef{
+		ID:           id,
+		FlowProperty: ref,
+		Mean:         mean,
+	})
+	return b
+}
+
+// SetReferenceProperty sets the internal ID of the reference flow property of
+// the flow.
+func (b *FlowBuilder) SetReferenceProperty(id int) *FlowBuilder {
+	b.flow.QRef = id
+	return b
+}
+
+// Build returns the flow of the builder.
+func (b *FlowBuilder) Build() *Flow {
+	return b.flow
+}
+
+// ProcessBuilder can be used to construct a new process data set in the same
+// way as flows are constructed with a FlowBuilder.
+type ProcessBuilder struct {
+	process *Process
+}
+
+// NewProcess creates a new builder for a process with the given UUID.
+func NewProcess(uuid string) *ProcessBuilder {
+	return &ProcessBuilder{process: &Process{
+		Info: &ProcessInfo{
+			UUID: uuid,
+			Name: &ProcessName{},
+		},
+		Publication: &CommonPublication{},
+	}}
+}
+
+// SetBaseName sets the base name of the process in the given language.
+func (b *ProcessBuilder) SetBaseName(lang, name string) *ProcessBuilder {
+	b.process.Info.Name.BaseName = withLang(b.process.Info.Name.BaseName, lang, name)
+	return b
+}
+
+// SetType sets the type of the process. For UnknownProcessType or another
+// value that is not a valid ILCD process type, the type is removed from the
+// process so that no invalid type is written.
+func (b *ProcessBuilder) SetType(t ProcessType) *ProcessBuilder {
+	switch t {
+	case UnitProcessSingleOperation, UnitProcessBlackBox, LCIResult,
+		PartlyTerminatedSystem, AvatarProcess:
+		if b.process.Method == nil {
+			b.process.Method = &ProcessMethod{}
+		}
+		b.process.Method.Type = t.String()
+	default:
+		if b.process.Method != nil {
+			b.process.Method.Type = ""
+		}
+	}
+	return b
+}
+
+// SetVersion sets the version of the process.
+func (b *ProcessBuilder) SetVersion(version string) *ProcessBuilder {
+	b.process.Publication.Version = version
+	return b
+}
+
+// SetLocation sets the location code of the process.
+func (b *ProcessBuilder) SetLocation(code string) *ProcessBuilder {
+	if b.process.Location == nil {
+		b.process.Location = &ProcessLocation{}
+	}
+	b.process.Location.Code = code
+	return b
+}
+
+// AddExchange adds an exchange with the given internal ID, flow reference,
+// direction, and amount to the process. The amount is set as mean and
+// resulting amount of the exchange.
+func (b *ProcessBuilder) AddExchange(id int, flow *Ref, direction Direction,
+	amount float64) *ProcessBuilder {
+	b.process.Exchanges = append(b.process.Exchanges, Exchange{
+		InternalID:      id,
+		Flow:            flow,
+		Direction:       string(direction),
+		MeanAmount:      amount,
+		ResultingAmount: amount,
+	})
+	return b
+}
+
+// SetReferenceExchange sets the exchange with the given internal ID as the
+// reference flow of the process.
+func (b *ProcessBuilder) SetReferenceExchange(id int) *ProcessBuilder {
+	b.process.QRef = &ProcessQRef{
+		Type:     "Reference flow(s)",
+		RefFlows: []int{id},
+	}
+	return b
+}
+
+// Build returns the process of the builder.
+func (b *ProcessBuilder) Build() *Process {
+	return b.process
+}
@@ -0,0 +1,87 @@
+package ilcd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+)
+
+func TestFlowBuilder(t *testing.T) {
+	massRef := &Ref{UUID: "93a60a56-a3c8-11da-a746-0800200b9a66", Type: "flow property data set"}
+	flow := NewFlow("08a91e70-3ddc-11dd-923d-0050c2490048").
+		SetBaseName("en", "carbon dioxide").
+		SetBaseName("de", "Kohlendioxid").
+		SetBaseName("en", "carbon dioxide, fossil").
+		SetType(ElementaryFlow).
+		SetVersion("01.00.000").
+		AddFlowProperty(0, massRef, 1.0).
+		SetReferenceProperty(0).
+		Build()
+	data, err := xml.Marshal(flow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := ReadFlow(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.UUID() != "08a91e70-3ddc-11dd-923d-0050c2490048" || f.Version() != "01.00.000" {
+		t.Fatal("wrong UUID or version")
+	}
+	if f.Info.Name.BaseName.Get("en") != "carbon dioxide, fossil" || len(f.Info.Name.BaseName) != 2 {
+		t.Fatal("wrong base name")
+	}
+	if f.FlowType() != ElementaryFlow {
+		t.Fatal("wrong flow type")
+	}
+	if f.ReferenceFlowProperty().FlowProperty.UUID != massRef.UUID {
+		t.Fatal("wrong reference flow property")
+	}
+}
+
+func TestProcessBuilder(t *testing.T) {
+	flowRef := &Ref{UUID: "08a91e70-3ddc-11dd-923d-0050c2490048", Type: "flow data set"}
+	process := NewProcess("c93541fe-0b28-40b8-a890-9948e9f1d41f").
+		SetBaseName("en", "electricity production").
+		SetType(UnitProcessBlackBox).
+		SetLocation("DE").
+		AddExchange(1, flowRef, Output, 42).
+		SetReferenceExchange(1).
+		Build()
+	data, err := xml.Marshal(process)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := ReadProcess(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.FullName("en") != "electricity production" || p.Kind() != UnitProcessBlackBox {
+		t.Fatal("wrong name or type")
+	}
+	ref := p.ReferenceExchange()
+	if ref == nil || !ref.IsOutput() || ref.MeanAmount != 42 || p.Location.Code != "DE" {
+		t.Fatal("wrong reference exchange or location")
+	}
+}
+
+func TestProcessBuilderUnknownType(t *testing.T) {
+	process := NewProcess("c93541fe-0b28-40b8-a890-9948e9f1d41f").
+		SetType(UnknownProcessType).
+		Build()
+	data, err := xml.Marshal(process)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("typeOfDataSet")) {
+		t.Fatal("no type should be written for an unknown process type:", string(data))
+	}
+
+	process = NewProcess("c93541fe-0b28-40b8-a890-9948e9f1d41f").
+		SetType(LCIResult).
+		SetType(UnknownProcessType).
+		Build()
+	if process.Method.Type != "" || process.Kind() != UnknownProcessType {
+		t.Fatal("the type should be removed, got", process.Method.Type)
+	}
+}
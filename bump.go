@@ -0,0 +1,98 @@
+package ilcd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	dataSetVersionRegex = regexp.MustCompile(
+		`(<(?:[\w.-]+:)?dataSetVersion>)[^<]*(</(?:[\w.-]+:)?dataSetVersion>)`)
+	refTagRegex      = regexp.MustCompile(`<[^<>]*\brefObjectId="[^"]*"[^<>]*>`)
+	refIDAttrRegex   = regexp.MustCompile(`\brefObjectId="([^"]*)"`)
+	versionAttrRegex = regexp.MustCompile(`(\bversion=")[^"]*(")`)
+	uriAttrRegex     = regexp.MustCompile(`(\buri=")([^"]*)(")`)
+)
+
+// BumpVersions copies the package of the given reader to the given writer and
+// sets the versions of all data sets to the given version. The version is
+// replaced in the `dataSetVersion` elements and in the entry names that
+// contain a version, like `<uuid>_<version>.xml`. The references to data sets
+// of the package are updated too: their `version` attributes are set to the
+// new version and their URIs are changed to the new entry names. The XML is
+// rewritten textually so that everything else, including elements that are
+// not captured by the data set structures, is kept unchanged. Other entries
+// are copied as they are. It returns an error if the given version is not a
+// valid data set version.
+func BumpVersions(r *ZipReader, w *ZipWriter, newVersion string) error {
+	if !IsValidVersion(newVersion) {
+		return fmt.Errorf("invalid data set version: %q", newVersion)
+	}
+	idx := r.index()
+	dataSets := make(map[string]bool)
+	renamed := make(map[string]string)
+	for _, entries := range idx.Entries {
+		for _, e := range entries {
+			dataSets[e.Name] = true
+			if e.Version == "" {
+				continue
+			}
+			base := path.Base(e.Name)
+			renamed[base] = strings.Replace(base, "_"+e.Version, "_"+newVersion, 1)
+		}
+	}
+
+	return r.Transform(w, func(name string, data []byte) (string, []byte, bool, error) {
+		if !dataSets[name] {
+			return name, data, true, nil
+		}
+		compressed := isGzip(data)
+		data, err := gunzip(data)
+		if err != nil {
+			return "", nil, false, err
+		}
+		data = dataSetVersionRegex.ReplaceAll(data, []byte("${1}"+newVersion+"${2}"))
+		data = refTagRegex.ReplaceAllFunc(data, func(tag []byte) []byte {
+			id := refIDAttrRegex.FindSubmatch(tag)
+			if id == nil || idx.Entries[strings.ToLower(string(id[1]))] == nil {
+				return tag
+			}
+			tag = versionAttrRegex.ReplaceAll(tag, []byte("${1}"+newVersion+"${2}"))
+			return uriAttrRegex.ReplaceAllFunc(tag, func(attr []byte) []byte {
+				parts := uriAttrRegex.FindSubmatch(attr)
+				uri := string(parts[2])
+				dir, base := path.Split(uri)
+				if newBase, ok := renamed[base]; ok {
+					uri = dir + newBase
+				}
+				return []byte(string(parts[1]) + uri + string(parts[3]))
+			})
+		})
+		if compressed {
+			if data, err = gzipBytes(data); err != nil {
+				return "", nil, false, err
+			}
+		}
+		if newBase, ok := renamed[path.Base(name)]; ok {
+			name = path.Join(path.Dir(name), newBase)
+		}
+		return name, data, true, nil
+	})
+}
+
+// gzipBytes compresses the given data with gzip.
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
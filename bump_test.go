@@ -0,0 +1,66 @@
+package ilcd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBumpVersions(t *testing.T) {
+	r := openTestZip(t)
+	path := filepath.Join(t.TempDir(), "bumped.zip")
+	w, err := NewZipWriter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := BumpVersions(r, w, "1.0"); err == nil {
+		t.Fatal("expected an error for an invalid version")
+	}
+	if err := BumpVersions(r, w, "42.00.000"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	bumped, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bumped.Close()
+
+	count := 0
+	bumped.EachDataSetFile(func(f *ZipFile) bool {
+		count++
+		if !strings.HasSuffix(f.Path(), "_42.00.000.xml") {
+			t.Fatal("the entry name was not updated:", f.Path())
+		}
+		ds, err := f.ReadDataSet()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ds.Version() != "42.00.000" {
+			t.Fatal("the version was not updated:", f.Path(), ds.Version())
+		}
+		return true
+	})
+	if count != 7 {
+		t.Fatal("expected 7 data sets, got", count)
+	}
+
+	p, err := bumped.GetProcess("c93541fe-0b28-40b8-a890-9948e9f1d41f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range p.Exchanges {
+		switch e.Flow.UUID {
+		case "fe0acd60-3ddc-11dd-aaa4-0050c2490048":
+			if e.Flow.Version != "42.00.000" {
+				t.Fatal("the reference to the flow in the package was not updated")
+			}
+		default:
+			if e.Flow.Version == "42.00.000" {
+				t.Fatal("references to other data sets should not be updated")
+			}
+		}
+	}
+}
@@ -1,26 +1,104 @@
 package ilcd
 
 import (
+	"bytes"
 	"encoding/xml"
+	"io"
+	"sort"
 )
 
 // CategorySystem contains categories that can be used in the data sets.
 type CategorySystem struct {
-	XMLName       xml.Name       `xml:"CategorySystem"`
-	Name          string         `xml:"name,attr,omitempty"`
-	CategoryLists []CategoryList `xml:"categories"`
+	XMLName       xml.Name       `xml:"CategorySystem" json:"-"`
+	Name          string         `xml:"name,attr,omitempty" json:"name,omitempty"`
+	CategoryLists []CategoryList `xml:"categories" json:"categoryLists,omitempty"`
 }
 
 // CategoryList contains the root categories of a category system for a data
 // set type.
 type CategoryList struct {
-	DataType   string     `xml:"dataType,attr,omitempty"`
-	Categories []Category `xml:"category"`
+	DataType   string     `xml:"dataType,attr,omitempty" json:"dataType,omitempty"`
+	Categories []Category `xml:"category" json:"categories,omitempty"`
 }
 
 // Category contains the information of a category in a category system.
 type Category struct {
-	ID     string     `xml:"id,attr,omitempty"`
-	Name   string     `xml:"name,attr,omitempty"`
-	Childs []Category `xml:"category"`
+	ID     string     `xml:"id,attr,omitempty" json:"id,omitempty"`
+	Name   string     `xml:"name,attr,omitempty" json:"name,omitempty"`
+	Childs []Category `xml:"category" json:"childs,omitempty"`
+}
+
+// ClassificationSystems returns the distinct classes of all data sets in the
+// package grouped by the names of their classification systems. The
+// classification system of a classification without a name is `ILCD`, the
+// default of the ILCD format. The classes of a system are sorted by level and
+// name. Note that the categories of elementary flows (the compartments) are
+// not contained in the result.
+func (r *ZipReader) ClassificationSystems() (map[string][]Class, error) {
+	systems := make(map[string][]Class)
+	seen := make(map[string]map[Class]bool)
+	var gerr error
+	r.EachDataSetFile(func(f *ZipFile) bool {
+		data, err := r.readData(f)
+		if err != nil {
+			gerr = err
+			return false
+		}
+		classifications, err := findClassifications(data)
+		if err != nil {
+			gerr = entryErr(f.Path(), err)
+			return false
+		}
+		for _, c := range classifications {
+			name := c.Name
+			if name == "" {
+				name = "ILCD"
+			}
+			if seen[name] == nil {
+				seen[name] = make(map[Class]bool)
+			}
+			for _, class := range c.Classes {
+				if seen[name][class] {
+					continue
+				}
+				seen[name][class] = true
+				systems[name] = append(systems[name], class)
+			}
+		}
+		return true
+	})
+	for _, classes := range systems {
+		sort.Slice(classes, func(i, j int) bool {
+			if classes[i].Level != classes[j].Level {
+				return classes[i].Level < classes[j].Level
+			}
+			return classes[i].Name < classes[j].Name
+		})
+	}
+	return systems, gerr
+}
+
+// findClassifications returns all classifications in the given XML data,
+// regardless of where they are located in the data set.
+func findClassifications(data []byte) ([]Classification, error) {
+	var classifications []Classification
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return classifications, nil
+		}
+		if err != nil {
+			return classifications, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "classification" {
+			continue
+		}
+		var c Classification
+		if err := decoder.DecodeElement(&c, &start); err != nil {
+			return classifications, err
+		}
+		classifications = append(classifications, c)
+	}
 }
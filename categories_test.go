@@ -0,0 +1,28 @@
+package ilcd
+
+import "testing"
+
+func TestClassificationSystems(t *testing.T) {
+	r := openTestZip(t)
+	systems, err := r.ClassificationSystems()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(systems) != 2 {
+		t.Fatal("expected 2 classification systems, got", systems)
+	}
+	gabi := systems["GaBiCategories"]
+	if len(gabi) != 6 || gabi[0].Name != "Processes" || gabi[1].Name != "Units" ||
+		gabi[5].Name != "Electricity" || gabi[5].Level != 3 {
+		t.Fatal("unexpected GaBi classes", gabi)
+	}
+	found := false
+	for _, class := range systems["ILCD"] {
+		if class.Level == 1 && class.Name == "Governmental organisations" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("classifications without name should be in the ILCD system", systems["ILCD"])
+	}
+}
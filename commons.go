@@ -1,12 +1,20 @@
 package ilcd
 
+import (
+	"encoding/xml"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+)
+
 // LangString is an ILCD multi-language string
 type LangString []LangStringItem
 
 // LangStringItem represents an item in an ILCD multi-language string
 type LangStringItem struct {
-	Value string `xml:",chardata"`
-	Lang  string `xml:"lang,attr"`
+	Value string `xml:",chardata" json:"value,omitempty"`
+	Lang  string `xml:"lang,attr" json:"lang,omitempty"`
 }
 
 // Get returns the value for the given language code
@@ -36,13 +44,28 @@ func (ls LangString) Default() string {
 	return ls[0].Value
 }
 
+// Languages returns the language codes of the non-empty values of the
+// multi-language string in the order of their appearance.
+func (ls LangString) Languages() []string {
+	var langs []string
+	seen := make(map[string]bool)
+	for _, item := range ls {
+		if strings.TrimSpace(item.Value) == "" || seen[item.Lang] {
+			continue
+		}
+		seen[item.Lang] = true
+		langs = append(langs, item.Lang)
+	}
+	return langs
+}
+
 // Ref is a data set reference to an ILCD data set.
 type Ref struct {
-	UUID    string     `xml:"refObjectId,attr"`
-	Type    string     `xml:"type,attr"`
-	URI     string     `xml:"uri,attr"`
-	Version string     `xml:"version,attr"`
-	Name    LangString `xml:"shortDescription"`
+	UUID    string     `xml:"refObjectId,attr" json:"uuid,omitempty"`
+	Type    string     `xml:"type,attr" json:"type,omitempty"`
+	URI     string     `xml:"uri,attr" json:"uri,omitempty"`
+	Version string     `xml:"version,attr" json:"version,omitempty"`
+	Name    LangString `xml:"shortDescription" json:"name,omitempty"`
 }
 
 // DataSetType returns the type of the data set referenced as defined in the ILCD
@@ -66,6 +89,8 @@ func (ref *Ref) DataSetType() DataSetType {
 		return ContactDataSet
 	case "LCIA method data set":
 		return MethodDataSet
+	case "life cycle model data set":
+		return ModelDataSet
 	case "other external file":
 		return ExternalDoc
 	default:
@@ -73,10 +98,51 @@ func (ref *Ref) DataSetType() DataSetType {
 	}
 }
 
+// ResolvedURI resolves the URI of the reference against the given base. The
+// base can be an URL (e.g. of the node where the data set with the reference
+// was fetched from) or the path of that data set within a package. If the URI
+// of the reference is already absolute, it is returned unchanged.
+func (ref *Ref) ResolvedURI(base string) string {
+	if ref == nil || ref.URI == "" {
+		return ""
+	}
+	uri, err := url.Parse(ref.URI)
+	if err != nil {
+		return ref.URI
+	}
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return ref.URI
+	}
+	resolved := baseURL.ResolveReference(uri).String()
+	if baseURL.Scheme == "" && baseURL.Host == "" && !strings.HasPrefix(base, "/") {
+		// keep package paths relative
+		resolved = strings.TrimPrefix(resolved, "/")
+	}
+	return resolved
+}
+
+// IsLocalURI returns true if the given URI points to a resource within a
+// package (a relative or absolute path without a host) and false if it points
+// to a remote resource that needs to be fetched from a network node.
+func IsLocalURI(uri string) bool {
+	if uri == "" {
+		return false
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "file" {
+		return true
+	}
+	return u.Scheme == "" && u.Host == ""
+}
+
 // Classification describes an ILCD classification entry in a data set
 type Classification struct {
-	Name    string  `xml:"name,attr"`
-	Classes []Class `xml:"class"`
+	Name    string  `xml:"name,attr" json:"name,omitempty"`
+	Classes []Class `xml:"class" json:"classes,omitempty"`
 }
 
 // GetClass returns the class with the given level from the classification.
@@ -94,19 +160,113 @@ func (c *Classification) GetClass(level int) *Class {
 
 // Class is a category in an ILCD data set classification.
 type Class struct {
-	Level int    `xml:"level,attr"`
-	ID    string `xml:"classId1,attr"`
-	Name  string `xml:",chardata"`
+	Level int    `xml:"level,attr" json:"level"`
+	ID    string `xml:"classId,attr" json:"id,omitempty"`
+	Name  string `xml:",chardata" json:"name,omitempty"`
+}
+
+// Other is a `common:other` block that contains extensions of the ILCD format,
+// like the elements of the EPD format or vendor specific data.
+type Other struct {
+	Elems []ExtensionElement `xml:",any" json:"elements,omitempty"`
+}
+
+// Elements returns the extension elements of the block.
+func (o *Other) Elements() []ExtensionElement {
+	if o == nil {
+		return nil
+	}
+	return o.Elems
+}
+
+// ExtensionElement is an element of an extension block. Its content is kept
+// as raw XML so that it can be parsed by applications that know the extension.
+type ExtensionElement struct {
+	XMLName  xml.Name   `json:"name"`
+	Attrs    []xml.Attr `xml:",any,attr" json:"attrs,omitempty"`
+	InnerXML string     `xml:",innerxml" json:"innerXml,omitempty"`
+}
+
+// Name returns the local name of the element.
+func (e *ExtensionElement) Name() string {
+	if e == nil {
+		return ""
+	}
+	return e.XMLName.Local
+}
+
+// Namespace returns the namespace URI of the element.
+func (e *ExtensionElement) Namespace() string {
+	if e == nil {
+		return ""
+	}
+	return e.XMLName.Space
+}
+
+// Attr returns the value of the attribute with the given local name or an
+// empty string if the element has no such attribute.
+func (e *ExtensionElement) Attr(name string) string {
+	if e == nil {
+		return ""
+	}
+	for _, attr := range e.Attrs {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
 }
 
 // CommonDataEntry <dataEntryBy>
 type CommonDataEntry struct {
-	TimeStamp   string `xml:"timeStamp"`
-	DataFormats []Ref  `xml:"referenceToDataSetFormat"`
+	TimeStamp   string `xml:"timeStamp" json:"timeStamp,omitempty"`
+	DataFormats []Ref  `xml:"referenceToDataSetFormat" json:"dataFormats,omitempty"`
+}
+
+// dataFormats returns the references to the data set formats of the data
+// entry section or nil if it is not defined.
+func (e *CommonDataEntry) dataFormats() []Ref {
+	if e == nil {
+		return nil
+	}
+	return e.DataFormats
+}
+
+// timeStamp returns the time stamp of the data entry section or an empty
+// string if it is not defined.
+func (e *CommonDataEntry) timeStamp() string {
+	if e == nil {
+		return ""
+	}
+	return e.TimeStamp
+}
+
+// timeStampLayouts are the layouts of xsd:dateTime values with and without
+// time zone; fractional seconds are accepted by both layouts when parsing.
+var timeStampLayouts = []string{
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02T15:04:05",
+}
+
+// parseTimeStamp parses the given time stamp in the xsd:dateTime format. Time
+// stamps without time zone are interpreted as UTC.
+func parseTimeStamp(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, errors.New("no time stamp")
+	}
+	var err error
+	for _, layout := range timeStampLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
 }
 
 // CommonPublication <publicationAndOwnership>
 type CommonPublication struct {
-	Version string `xml:"dataSetVersion"`
-	URI     string `xml:"permanentDataSetURI"`
+	Version string `xml:"dataSetVersion" json:"version,omitempty"`
+	URI     string `xml:"permanentDataSetURI" json:"uri,omitempty"`
 }
@@ -0,0 +1,129 @@
+package ilcd
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestResolvedURI(t *testing.T) {
+	ref := &Ref{URI: "../flows/fe0acd60-3ddc-11dd-aaa4-0050c2490048.xml"}
+	uri := ref.ResolvedURI("ILCD/processes/c93541fe-0b28-40b8-a890-9948e9f1d41f.xml")
+	if uri != "ILCD/flows/fe0acd60-3ddc-11dd-aaa4-0050c2490048.xml" {
+		t.Fatal("failed to resolve against a package path:", uri)
+	}
+	uri = ref.ResolvedURI("http://node.org/resource/processes/c93541fe.xml")
+	if uri != "http://node.org/resource/flows/fe0acd60-3ddc-11dd-aaa4-0050c2490048.xml" {
+		t.Fatal("failed to resolve against an URL:", uri)
+	}
+	ref = &Ref{URI: "http://node.org/flows/fe0acd60.xml"}
+	if ref.ResolvedURI("ILCD/processes/c93541fe.xml") != ref.URI {
+		t.Fatal("absolute URIs should not be changed")
+	}
+}
+
+func TestIsLocalURI(t *testing.T) {
+	local := []string{"../flows/a.xml", "ILCD/flows/a.xml", "/ILCD/a.xml", "file:///tmp/a.xml"}
+	for _, uri := range local {
+		if !IsLocalURI(uri) {
+			t.Fatal("should be local:", uri)
+		}
+	}
+	remote := []string{"", "http://node.org/a.xml", "https://node.org/a.xml"}
+	for _, uri := range remote {
+		if IsLocalURI(uri) {
+			t.Fatal("should not be local:", uri)
+		}
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	ls := LangString{{Lang: "en", Value: "a"}, {Lang: "de", Value: " "},
+		{Lang: "fr", Value: "b"}, {Lang: "en", Value: "c"}}
+	langs := ls.Languages()
+	if len(langs) != 2 || langs[0] != "en" || langs[1] != "fr" {
+		t.Fatal("unexpected languages", langs)
+	}
+}
+
+func TestParseTimeStamp(t *testing.T) {
+	tests := map[string]time.Time{
+		"2012-01-04T15:42:24.609+01:00": time.Date(2012, 1, 4, 14, 42, 24, 609000000, time.UTC),
+		"2011-09-14T15:29:07Z":          time.Date(2011, 9, 14, 15, 29, 7, 0, time.UTC),
+		" 2011-11-01T00:00:00 ":         time.Date(2011, 11, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for s, expected := range tests {
+		parsed, err := parseTimeStamp(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !parsed.Equal(expected) {
+			t.Fatal("unexpected time", s, parsed)
+		}
+	}
+	for _, s := range []string{"", "2011-11-01", "yesterday"} {
+		if _, err := parseTimeStamp(s); err == nil {
+			t.Fatal("expected an error for", s)
+		}
+	}
+}
+
+func TestModifiedAt(t *testing.T) {
+	p, _ := ReadProcessFile("sample_data/process.xml")
+	if p.TimeStamp() == "" {
+		t.Fatal("no time stamp")
+	}
+	modified, err := p.ModifiedAt()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if modified.Year() < 2000 {
+		t.Fatal("unexpected modification date", modified)
+	}
+	var nilFlow *Flow
+	if _, err := nilFlow.ModifiedAt(); err == nil {
+		t.Fatal("expected an error for a nil flow")
+	}
+}
+
+func TestDataSetExtensions(t *testing.T) {
+	roots := map[string]string{
+		"flowDataSet":           "flowInformation",
+		"flowPropertyDataSet":   "flowPropertiesInformation",
+		"unitGroupDataSet":      "unitGroupInformation",
+		"sourceDataSet":         "sourceInformation",
+		"contactDataSet":        "contactInformation",
+		"LCIAMethodDataSet":     "LCIAMethodInformation",
+		"lifeCycleModelDataSet": "lifeCycleModelInformation",
+	}
+	for root, info := range roots {
+		data := []byte(fmt.Sprintf(`<%[1]s
+			xmlns:common="http://lca.jrc.it/ILCD/Common"
+			xmlns:x="http://example.com/ext">
+			<%[2]s>
+				<dataSetInformation>
+					<common:other>
+						<x:vendorId>42</x:vendorId>
+					</common:other>
+				</dataSetInformation>
+			</%[2]s>
+		</%[1]s>`, root, info))
+		dsType, err := DataSetTypeOf(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ds := newDataSet(dsType)
+		if err := unmarshalXML(data, ds); err != nil {
+			t.Fatal(err)
+		}
+		exts := ds.(interface{ Extensions() []ExtensionElement }).Extensions()
+		if len(exts) != 1 || exts[0].Name() != "vendorId" ||
+			exts[0].Namespace() != "http://example.com/ext" || exts[0].InnerXML != "42" {
+			t.Fatal("unexpected extensions of", root, exts)
+		}
+		unknown, err := UnknownElements(data)
+		if err != nil || len(unknown) != 0 {
+			t.Fatal("the extensions of", root, "should be known, got", unknown, err)
+		}
+	}
+}
@@ -1,13 +1,16 @@
 package ilcd
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"time"
+)
 
 // Contact represents an ILCD contact data set
 type Contact struct {
-	XMLName     xml.Name           `xml:"contactDataSet"`
-	Info        *ContactInfo       `xml:"contactInformation>dataSetInformation"`
-	DataEntry   *CommonDataEntry   `xml:"administrativeInformation>dataEntryBy"`
-	Publication *CommonPublication `xml:"administrativeInformation>publicationAndOwnership"`
+	XMLName     xml.Name           `xml:"contactDataSet" json:"-"`
+	Info        *ContactInfo       `xml:"contactInformation>dataSetInformation" json:"info,omitempty"`
+	DataEntry   *CommonDataEntry   `xml:"administrativeInformation>dataEntryBy" json:"dataEntry,omitempty"`
+	Publication *CommonPublication `xml:"administrativeInformation>publicationAndOwnership" json:"publication,omitempty"`
 }
 
 // UUID returns the UUID of the data set.
@@ -26,14 +29,49 @@ func (c *Contact) Version() string {
 	return c.Publication.Version
 }
 
+// TimeStamp returns the time stamp of the last modification of the data set
+// as it is stored in the data entry section.
+func (c *Contact) TimeStamp() string {
+	if c == nil {
+		return ""
+	}
+	return c.DataEntry.timeStamp()
+}
+
+// ModifiedAt returns the parsed time stamp of the last modification of the
+// data set.
+func (c *Contact) ModifiedAt() (time.Time, error) {
+	return parseTimeStamp(c.TimeStamp())
+}
+
+// DataFormats returns the references to the data set formats, e.g. the ILCD
+// format, that are declared in the data entry section of the data set.
+func (c *Contact) DataFormats() []Ref {
+	if c == nil {
+		return nil
+	}
+	return c.DataEntry.dataFormats()
+}
+
+// Extensions returns the extension elements of the contact, e.g. vendor
+// specific data. These are the elements of the `other` block of the data set
+// information.
+func (c *Contact) Extensions() []ExtensionElement {
+	if c == nil || c.Info == nil {
+		return nil
+	}
+	return c.Info.Other.Elements()
+}
+
 // ContactInfo <dataSetInformation>
 type ContactInfo struct {
-	UUID            string           `xml:"UUID"`
-	ShortName       LangString       `xml:"shortName"`
-	Name            LangString       `xml:"name"`
-	Classifications []Classification `xml:"classificationInformation>classification"`
-	Address         LangString       `xml:"contactAddress"`
-	Email           string           `xml:"email,omitempty"`
-	URL             string           `xml:"WWWAddress,omitempty"`
-	Comment         LangString       `xml:"contactDescriptionOrComment"`
+	UUID            string           `xml:"UUID" json:"uuid,omitempty"`
+	ShortName       LangString       `xml:"shortName" json:"shortName,omitempty"`
+	Name            LangString       `xml:"name" json:"name,omitempty"`
+	Classifications []Classification `xml:"classificationInformation>classification" json:"classifications,omitempty"`
+	Address         LangString       `xml:"contactAddress" json:"address,omitempty"`
+	Email           string           `xml:"email,omitempty" json:"email,omitempty"`
+	URL             string           `xml:"WWWAddress,omitempty" json:"url,omitempty"`
+	Comment         LangString       `xml:"contactDescriptionOrComment" json:"comment,omitempty"`
+	Other           *Other           `xml:"other" json:"other,omitempty"`
 }
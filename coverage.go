@@ -0,0 +1,71 @@
+package ilcd
+
+// nameOf returns the name of the given data set as multi-language string. For
+// processes, flows, and models this is the base name.
+func nameOf(ds DataSet) LangString {
+	switch d := ds.(type) {
+	case *Model:
+		if d.Info != nil && d.Info.Name != nil {
+			return d.Info.Name.BaseName
+		}
+	case *Method:
+		if d.Info != nil {
+			return d.Info.Name
+		}
+	case *Process:
+		if d.Info != nil && d.Info.Name != nil {
+			return d.Info.Name.BaseName
+		}
+	case *Flow:
+		if d.Info != nil && d.Info.Name != nil {
+			return d.Info.Name.BaseName
+		}
+	case *FlowProperty:
+		if d.Info != nil {
+			return d.Info.Name
+		}
+	case *UnitGroup:
+		if d.Info != nil {
+			return d.Info.Name
+		}
+	case *Source:
+		if d.Info != nil {
+			return d.Info.ShortName
+		}
+	case *Contact:
+		if d.Info != nil {
+			return d.Info.ShortName
+		}
+	}
+	return nil
+}
+
+// LanguageCoverage returns for each data set type the number of data sets in
+// the package that have a name in a language. The names of sources and
+// contacts are their short names. This can be used to find missing
+// translations in a package.
+func (r *ZipReader) LanguageCoverage() (map[DataSetType]map[string]int, error) {
+	coverage := make(map[DataSetType]map[string]int)
+	var gerr error
+	r.EachDataSetFile(func(f *ZipFile) bool {
+		ds, err := f.ReadDataSet()
+		if err != nil {
+			gerr = entryErr(f.Path(), err)
+			return false
+		}
+		langs := nameOf(ds).Languages()
+		if len(langs) == 0 {
+			return true
+		}
+		counts := coverage[f.Type()]
+		if counts == nil {
+			counts = make(map[string]int)
+			coverage[f.Type()] = counts
+		}
+		for _, lang := range langs {
+			counts[lang]++
+		}
+		return true
+	})
+	return coverage, gerr
+}
@@ -0,0 +1,20 @@
+package ilcd
+
+import "testing"
+
+func TestLanguageCoverage(t *testing.T) {
+	r := openTestZip(t)
+	coverage, err := r.LanguageCoverage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, dsType := range []DataSetType{ProcessDataSet, FlowDataSet,
+		FlowPropertyDataSet, UnitGroupDataSet, SourceDataSet, ContactDataSet} {
+		if coverage[dsType]["en"] != 1 {
+			t.Fatal("expected an English name for", dsType, coverage[dsType])
+		}
+	}
+	if _, ok := coverage[ExternalDoc]; ok {
+		t.Fatal("external docs should not be covered")
+	}
+}
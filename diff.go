@@ -0,0 +1,98 @@
+package ilcd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"sort"
+	"strings"
+)
+
+// CanonicalHash returns a SHA-256 hash of the given data set in hexadecimal
+// form. The hash is calculated from the XML that this package writes for the
+// data set structure. Thus, data sets that only differ in formatting (e.g.
+// indentation or namespace prefixes) or in elements that are not captured by
+// the data set structures of this package have the same hash.
+func CanonicalHash(ds DataSet) (string, error) {
+	data, err := xml.Marshal(withoutXMLName(ds))
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// hashedRef is a data set reference with the canonical hash of the data set.
+type hashedRef struct {
+	ref  Ref
+	hash string
+}
+
+// DiffPackages compares the data sets of the package before and after a change.
+// Data sets are identified by their type and UUID. It returns references to
+// the data sets that were added to, removed from, or changed in the after
+// package. The URIs of these references are the paths of the data sets in the
+// after package or, for removed data sets, in the before package. Changes are
+// detected by comparing the canonical hashes of the data sets (see
+// CanonicalHash). If a package contains multiple versions of a data set, the
+// highest version is compared.
+func DiffPackages(before, after *ZipReader) (added, removed, changed []Ref, err error) {
+	oldRefs, err := hashedRefs(before)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	newRefs, err := hashedRefs(after)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for key, n := range newRefs {
+		o, ok := oldRefs[key]
+		if !ok {
+			added = append(added, n.ref)
+		} else if o.hash != n.hash {
+			changed = append(changed, n.ref)
+		}
+	}
+	for key, o := range oldRefs {
+		if _, ok := newRefs[key]; !ok {
+			removed = append(removed, o.ref)
+		}
+	}
+	sortRefs(added)
+	sortRefs(removed)
+	sortRefs(changed)
+	return added, removed, changed, nil
+}
+
+// hashedRefs returns the references and hashes of the data sets in the given
+// package mapped by `<type>/<uuid>`.
+func hashedRefs(r *ZipReader) (map[string]*hashedRef, error) {
+	refs := make(map[string]*hashedRef)
+	var gerr error
+	r.EachDataSetFile(func(f *ZipFile) bool {
+		ds, err := f.ReadDataSet()
+		if err != nil {
+			gerr = entryErr(f.Path(), err)
+			return false
+		}
+		key := f.Type().String() + "/" + strings.ToLower(ds.UUID())
+		if other, ok := refs[key]; ok && CompareVersions(other.ref.Version, ds.Version()) >= 0 {
+			return true
+		}
+		hash, err := CanonicalHash(ds)
+		if err != nil {
+			gerr = entryErr(f.Path(), err)
+			return false
+		}
+		refs[key] = &hashedRef{ref: RefOf(ds, f.Path()), hash: hash}
+		return true
+	})
+	return refs, gerr
+}
+
+// sortRefs sorts the given references by their URIs.
+func sortRefs(refs []Ref) {
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].URI < refs[j].URI
+	})
+}
@@ -0,0 +1,82 @@
+package ilcd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestCanonicalHash(t *testing.T) {
+	flow, _ := ReadFlowFile("sample_data/flow.xml")
+	h1, err := CanonicalHash(flow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := RoundTrip(mustRead(t, "sample_data/flow.xml"), FlowDataSet)
+	reread, _ := ReadFlow(data)
+	h2, err := CanonicalHash(reread)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h1 != h2 || len(h1) != 64 {
+		t.Fatal("hashes of the same data set differ")
+	}
+}
+
+func TestDiffPackages(t *testing.T) {
+	old := openTestZip(t)
+
+	path := filepath.Join(t.TempDir(), "new.zip")
+	w, err := NewZipWriter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = old.Transform(w, func(name string, data []byte) (string, []byte, bool, error) {
+		if IsContactPath(name) {
+			return "", nil, false, nil
+		}
+		if IsFlowPath(name) {
+			data = bytes.Replace(data, []byte(">air<"), []byte(">fresh air<"), 1)
+		}
+		return name, data, true, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	flow := NewFlow("08a91e70-3ddc-11dd-923d-0050c2490048").SetVersion("01.00.000").Build()
+	if err := w.WriteDataSet(flow); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	newPkg, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer newPkg.Close()
+
+	added, removed, changed, err := DiffPackages(old, newPkg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(added) != 1 || added[0].UUID != "08a91e70-3ddc-11dd-923d-0050c2490048" {
+		t.Fatal("unexpected added data sets", added)
+	}
+	if len(removed) != 1 || removed[0].UUID != "97f476bd-415a-4463-955a-019202b70ae4" {
+		t.Fatal("unexpected removed data sets", removed)
+	}
+	if len(changed) != 1 || changed[0].UUID != "fe0acd60-3ddc-11dd-aaa4-0050c2490048" {
+		t.Fatal("unexpected changed data sets", changed)
+	}
+}
+
+func mustRead(t *testing.T, file string) []byte {
+	t.Helper()
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
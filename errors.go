@@ -1,8 +1,62 @@
 package ilcd
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	// ErrDataSetNotFound indicates that a data set could not be found
 	ErrDataSetNotFound = errors.New("data set not found")
+
+	// ErrEntryNotFound indicates that an entry could not be found in a package
+	ErrEntryNotFound = errors.New("entry not found")
+
+	// ErrEncrypted indicates that an entry of a package is encrypted and cannot
+	// be read without (or with the given) password
+	ErrEncrypted = errors.New("entry is encrypted")
+
+	// ErrPassword indicates that an encrypted entry could not be decrypted
+	// with the given password
+	ErrPassword = errors.New("invalid password")
+
+	// ErrUnsafePath indicates that the name of a zip entry would point outside
+	// of the target directory when the entry is extracted
+	ErrUnsafePath = errors.New("unsafe entry path")
+
+	// ErrUnsupportedType indicates that an operation is not supported for a
+	// data set type
+	ErrUnsupportedType = errors.New("unsupported data set type")
+
+	// ErrSplitArchive indicates that a package is a volume of a split
+	// (multi-volume) zip archive which cannot be read directly; the volumes
+	// need to be joined into a single zip file first, e.g. with
+	// `zip -s 0 split.zip --out joined.zip`
+	ErrSplitArchive = errors.New("split zip archives are not supported")
+
+	// ErrInvalidFlowProperty indicates that a flow property of a flow has an
+	// invalid conversion factor or that the reference flow property is missing
+	ErrInvalidFlowProperty = errors.New("invalid flow property")
+
+	// ErrInvalidUUID indicates that a data set has no or a malformed UUID
+	ErrInvalidUUID = errors.New("invalid UUID")
+
+	// ErrNoReferenceFlow indicates that a process has no reference flow or
+	// that the amount of its reference flow is zero
+	ErrNoReferenceFlow = errors.New("no reference flow")
+
+	// ErrUnknownElements indicates that a data set contains elements that are
+	// not captured by the data set structures of this package and that would
+	// be lost when the data set is read in strict mode
+	ErrUnknownElements = errors.New("unknown elements")
 )
+
+// entryErr adds the name of the zip entry where the given error occurred to
+// the error message. The original error can be still checked via errors.Is
+// or errors.As.
+func entryErr(name string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("entry %q: %w", name, err)
+}
@@ -0,0 +1,179 @@
+package ilcd
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteMethodFactorsCSV writes the characterisation factors of the LCIA method
+// with the given UUID as CSV to the given writer. After a header row, there is
+// one row per factor with the UUID, name, and compartment of the flow, the
+// factor value, and the reference unit of the flow the factor is related to.
+// The compartment contains the top and sub-compartment separated by a slash.
+// If a flow is not contained in the package, its name is taken from the
+// description of the reference and the compartment and unit are empty; the
+// unit is also empty when it cannot be resolved. The rows are written while
+// iterating over the factors.
+func (r *ZipReader) WriteMethodFactorsCSV(w io.Writer, methodUUID string) error {
+	method, err := r.GetMethod(methodUUID)
+	if err != nil {
+		return err
+	}
+	units, err := NewUnitResolver(r)
+	if err != nil {
+		return err
+	}
+	writer := csv.NewWriter(w)
+	header := []string{"Flow UUID", "Flow", "Compartment", "Factor", "Unit"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for i := range method.Factors {
+		factor := &method.Factors[i]
+		if factor.Flow == nil {
+			continue
+		}
+		record := []string{factor.Flow.UUID, factor.Flow.Name.Default(), "",
+			strconv.FormatFloat(factor.MeanValue, 'g', -1, 64), ""}
+		flow, err := r.GetFlow(factor.Flow.UUID)
+		if err != nil && !errors.Is(err, ErrDataSetNotFound) {
+			return err
+		}
+		if flow != nil {
+			if name := nameOf(flow).Default(); name != "" {
+				record[1] = name
+			}
+			top, sub := flow.Compartment()
+			record[2] = top
+			if sub != "" {
+				record[2] += "/" + sub
+			}
+			if unit, err := units.UnitOf(flow.UUID()); err == nil {
+				record[4] = unit
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportProcess writes the process with the given UUID together with all data
+// sets it depends on into the given writer, so that the written package is
+// self-contained and can be shared without the rest of this package. The
+// dependencies are collected transitively by following the data set
+// references, e.g. from the process to its flows and contacts, from the flows
+// to their flow properties, and from the flow properties to their unit groups.
+// The digital files of the sources in the `external_docs` folder are exported
+// too. The entries are copied as they are under their original paths.
+// References to data sets or files that are not contained in this package are
+// ignored. It returns ErrDataSetNotFound if the process is not contained in
+// the package.
+func (r *ZipReader) ExportProcess(processUUID string, w *ZipWriter) error {
+	f := r.FindDataSet(ProcessDataSet, processUUID)
+	if f == nil {
+		return fmt.Errorf("%w: process %s", ErrDataSetNotFound, processUUID)
+	}
+	written := map[string]bool{f.Path(): true}
+	queue := []*ZipFile{f}
+	for len(queue) > 0 {
+		f := queue[0]
+		queue = queue[1:]
+		if err := w.CopyEntry(f, PreserveMethod); err != nil {
+			return entryErr(f.Path(), err)
+		}
+		data, err := r.readData(f)
+		if err != nil {
+			return err
+		}
+		refs, err := findRefs(data)
+		if err != nil {
+			return entryErr(f.Path(), err)
+		}
+		for i := range refs {
+			dep := r.findRef(&refs[i])
+			if dep != nil && !written[dep.Path()] {
+				written[dep.Path()] = true
+				queue = append(queue, dep)
+			}
+		}
+		if f.Type() != SourceDataSet {
+			continue
+		}
+		docs, err := r.sourceFiles(data)
+		if err != nil {
+			return entryErr(f.Path(), err)
+		}
+		for _, doc := range docs {
+			if written[doc.Path()] {
+				continue
+			}
+			written[doc.Path()] = true
+			if err := w.CopyEntry(doc, PreserveMethod); err != nil {
+				return entryErr(doc.Path(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// findRef returns the zip file of the data set that is referenced by the given
+// reference; see findRefIn. It returns nil if the package does not contain
+// the data set.
+func (r *ZipReader) findRef(ref *Ref) *ZipFile {
+	f, _ := findRefIn([]*ZipReader{r}, ref)
+	return f
+}
+
+// findRefIn returns the zip file of the data set that is referenced by the
+// given reference together with the reader of the package that contains it.
+// If the reference has a version, the first package that contains the data
+// set with exactly that version is used; otherwise, or if no package contains
+// that version, the latest version of the first package that contains the
+// data set is returned. It returns nil if no package contains the data set.
+func findRefIn(readers []*ZipReader, ref *Ref) (*ZipFile, *ZipReader) {
+	dsType := ref.DataSetType()
+	if newDataSet(dsType) == nil {
+		return nil, nil
+	}
+	if strings.TrimSpace(ref.Version) != "" {
+		for _, r := range readers {
+			if f := r.FindDataSetVersion(dsType, ref.UUID, ref.Version); f != nil {
+				return f, r
+			}
+		}
+	}
+	for _, r := range readers {
+		if f := r.FindDataSet(dsType, ref.UUID); f != nil {
+			return f, r
+		}
+	}
+	return nil, nil
+}
+
+// sourceFiles returns the zip files of the digital files of the source with
+// the given data that are contained in the `external_docs` folder of the
+// package. Files that are referenced by a web URL or that are not contained in
+// the package are ignored.
+func (r *ZipReader) sourceFiles(data []byte) ([]*ZipFile, error) {
+	source, err := ReadSource(data)
+	if err != nil {
+		return nil, err
+	}
+	var files []*ZipFile
+	for _, uri := range source.FileURIs() {
+		if !IsLocalURI(uri) {
+			continue
+		}
+		if f := r.FindExternalDoc(localFileName(uri)); f != nil {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,136 @@
+package ilcd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestWriteMethodFactorsCSV(t *testing.T) {
+	r := openTestZip(t)
+
+	// link the first factor with the sample flow and the flow property with
+	// the sample unit group
+	path := filepath.Join(t.TempDir(), "method.zip")
+	w, err := NewZipWriter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.Transform(w, func(name string, data []byte) (string, []byte, bool, error) {
+		data = bytes.ReplaceAll(data, []byte("93a60a57-a4c8-11da-a746-0800200c9a66"),
+			[]byte("ad38d542-3fe9-439d-9b95-2f5f7752acaf"))
+		data = bytes.ReplaceAll(data, []byte("08a91e70-3ddc-11dd-9787-0050c2490048"),
+			[]byte("fe0acd60-3ddc-11dd-aaa4-0050c2490048"))
+		return name, data, true, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	linked, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer linked.Close()
+
+	var buf bytes.Buffer
+	if err := linked.WriteMethodFactorsCSV(&buf, "992c8e8d-769a-4930-9b0f-4fa323250738"); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 10 || records[0][0] != "Flow UUID" {
+		t.Fatal("expected a header and 9 factors, got", records)
+	}
+	flow, _ := ReadFlowFile("sample_data/flow.xml")
+	top, sub := flow.Compartment()
+	first := records[1]
+	if first[0] != "fe0acd60-3ddc-11dd-aaa4-0050c2490048" || first[1] != flow.Info.Name.BaseName.Default() ||
+		first[2] != top+"/"+sub || first[3] != "1.45e-08" || first[4] != "kg" {
+		t.Fatal("unexpected first factor", first)
+	}
+	second := records[2]
+	if second[1] == "" || second[2] != "" || second[4] != "" {
+		t.Fatal("unexpected second factor", second)
+	}
+}
+
+func TestExportProcess(t *testing.T) {
+	r := openTestZip(t)
+
+	// link the process with the sample contact and source, and the flow
+	// property with the sample unit group
+	path := filepath.Join(t.TempDir(), "linked.zip")
+	w, err := NewZipWriter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.Transform(w, func(name string, data []byte) (string, []byte, bool, error) {
+		data = bytes.ReplaceAll(data, []byte("93a60a57-a4c8-11da-a746-0800200c9a66"),
+			[]byte("ad38d542-3fe9-439d-9b95-2f5f7752acaf"))
+		data = bytes.ReplaceAll(data, []byte("0660d718-efb7-4ead-ba20-e78c5fc77d00"),
+			[]byte("97f476bd-415a-4463-955a-019202b70ae4"))
+		data = bytes.ReplaceAll(data, []byte("010e303b-7e52-4a01-b176-4ac26f4b7eac"),
+			[]byte("220580af-2c84-4e60-82ed-c30a1c6f63f5"))
+		return name, data, true, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	linked, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer linked.Close()
+
+	path = filepath.Join(t.TempDir(), "export.zip")
+	w, err = NewZipWriter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := linked.ExportProcess("c93541fe-0b28-40b8-a890-9948e9f1d41f", w); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	exported, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer exported.Close()
+	var folders []string
+	exported.EachFile(func(f *ZipFile) bool {
+		folders = append(folders, strings.Split(f.Path(), "/")[1])
+		return true
+	})
+	sort.Strings(folders)
+	expected := "contacts external_docs flowproperties flows processes sources unitgroups"
+	if strings.Join(folders, " ") != expected {
+		t.Fatal("unexpected entries in exported package:", folders)
+	}
+	if _, err := exported.GetUnitGroup("ad38d542-3fe9-439d-9b95-2f5f7752acaf"); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err = NewZipWriter(filepath.Join(t.TempDir(), "missing.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	err = linked.ExportProcess("fe0acd60-3ddc-11dd-aaa4-0050c2490048", w)
+	if !errors.Is(err, ErrDataSetNotFound) {
+		t.Fatal("expected ErrDataSetNotFound, got", err)
+	}
+}
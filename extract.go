@@ -0,0 +1,125 @@
+package ilcd
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ExtractTo writes all entries of the package into the given directory,
+// preserving the folder structure of the package. Entries with names that
+// would point outside of the given directory (e.g. `../../etc/passwd`) are
+// rejected with an error.
+func (r *ZipReader) ExtractTo(dir string) error {
+	base, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	for _, f := range r.r.File {
+		target, err := safeJoin(base, f.Name)
+		if err != nil {
+			return err
+		}
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := extractFile(r.file(f), target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExtractSourceFiles writes the digital files of the source with the given
+// UUID from the `external_docs` folder of the package into the given
+// directory. Files that are referenced by a web URL are skipped. It returns an
+// error that wraps ErrEntryNotFound if a local file is not contained in the
+// package.
+func (r *ZipReader) ExtractSourceFiles(sourceUUID, destDir string) error {
+	source, err := r.GetSource(sourceUUID)
+	if err != nil {
+		return err
+	}
+	base, err := filepath.Abs(destDir)
+	if err != nil {
+		return err
+	}
+	for _, uri := range source.FileURIs() {
+		if !IsLocalURI(uri) {
+			continue
+		}
+		name := localFileName(uri)
+		f := r.FindExternalDoc(name)
+		if f == nil {
+			return fmt.Errorf("%w: %s of source %s", ErrEntryNotFound, name, sourceUUID)
+		}
+		target, err := safeJoin(base, name)
+		if err != nil {
+			return err
+		}
+		if err := extractFile(f, target); err != nil {
+			return entryErr(f.Path(), err)
+		}
+	}
+	return nil
+}
+
+// localFileName returns the unescaped file name of the given local URI of a
+// digital file, e.g. `blank.JPG` for `../external_docs/blank.JPG`.
+func localFileName(uri string) string {
+	name := path.Base(strings.Replace(uri, "\\", "/", -1))
+	if u, err := url.PathUnescape(name); err == nil {
+		name = u
+	}
+	return name
+}
+
+// safeJoin joins the given base directory and the name of a zip entry. It
+// returns an error that wraps ErrUnsafePath if the name is an absolute path or
+// if the joined path would point outside of the base directory (zip-slip).
+// Backslashes in the name are handled as path separators as some tools write
+// Windows paths into zip packages. All code that writes zip entries to disk
+// must use this function to calculate the target path.
+func safeJoin(base, name string) (string, error) {
+	slashed := strings.Replace(name, "\\", "/", -1)
+	if slashed == "" || path.IsAbs(slashed) || filepath.VolumeName(slashed) != "" ||
+		(len(slashed) > 1 && slashed[1] == ':') {
+		return "", fmt.Errorf("%w: %q", ErrUnsafePath, name)
+	}
+	cleanBase := filepath.Clean(base)
+	target := filepath.Join(cleanBase, filepath.FromSlash(slashed))
+	if target == cleanBase ||
+		strings.HasPrefix(target, cleanBase+string(os.PathSeparator)) {
+		return target, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnsafePath, name)
+}
+
+// extractFile streams the content of the given zip file into the file at the
+// given path.
+func extractFile(f *ZipFile, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	reader, err := f.open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, reader); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
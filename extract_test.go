@@ -0,0 +1,110 @@
+package ilcd
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExtractTo(t *testing.T) {
+	r := openTestZip(t)
+	dir := t.TempDir()
+	if err := r.ExtractTo(dir); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dir, "ILCD", "external_docs", "blank.JPG"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "no image" {
+		t.Fatal("wrong content of extracted file")
+	}
+	flow, err := ReadFlowFile(filepath.Join(dir, "ILCD", "flows",
+		"fe0acd60-3ddc-11dd-aaa4-0050c2490048_03.00.000.xml"))
+	if err != nil || flow.UUID() != "fe0acd60-3ddc-11dd-aaa4-0050c2490048" {
+		t.Fatal("failed to extract flow", err)
+	}
+}
+
+func TestSafeJoin(t *testing.T) {
+	base := filepath.Join("tmp", "out")
+	valid := map[string]string{
+		"ILCD/flows/a.xml":    filepath.Join(base, "ILCD", "flows", "a.xml"),
+		"ILCD\\flows\\a.xml":  filepath.Join(base, "ILCD", "flows", "a.xml"),
+		"ILCD/../flows/a.xml": filepath.Join(base, "flows", "a.xml"),
+	}
+	for name, expected := range valid {
+		target, err := safeJoin(base, name)
+		if err != nil || target != expected {
+			t.Fatal("failed to join", name, target, err)
+		}
+	}
+	invalid := []string{"", "../a.xml", "ILCD/../../a.xml", "..\\a.xml",
+		"/etc/passwd", "C:\\Windows\\a.dll"}
+	for _, name := range invalid {
+		if _, err := safeJoin(base, name); !errors.Is(err, ErrUnsafePath) {
+			t.Fatal("should be rejected:", name)
+		}
+	}
+}
+
+func TestExtractMaliciousZip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "malicious.zip")
+	w, _ := NewZipWriter(path)
+	w.Write("ILCD/flows/a.xml", []byte("<flowDataSet/>"))
+	w.Write("../../evil.txt", []byte("evil"))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	root := t.TempDir()
+	dir := filepath.Join(root, "a", "b")
+	if err := r.ExtractTo(dir); !errors.Is(err, ErrUnsafePath) {
+		t.Fatal("extracting a zip-slip entry should fail, got", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "evil.txt")); !os.IsNotExist(err) {
+		t.Fatal("the malicious entry was written outside of the target directory")
+	}
+}
+
+func TestExtractSourceFiles(t *testing.T) {
+	r := openTestZip(t)
+	dir := t.TempDir()
+	if err := r.ExtractSourceFiles("220580af-2c84-4e60-82ed-c30a1c6f63f5", dir); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dir, "blank.JPG"))
+	if err != nil || string(data) != "no image" {
+		t.Fatal("failed to extract source file", err)
+	}
+
+	source := []byte(`<sourceDataSet xmlns:common="http://lca.jrc.it/ILCD/Common">
+		<sourceInformation><dataSetInformation>
+			<common:UUID>9ba3ac1e-6797-4cc0-afd5-1b8f7bf28c6a</common:UUID>
+			<referenceToDigitalFile uri="https://example.com/report.pdf"/>
+			<referenceToDigitalFile uri="../external_docs/missing.pdf"/>
+		</dataSetInformation></sourceInformation>
+	</sourceDataSet>`)
+	path := filepath.Join(t.TempDir(), "sources.zip")
+	err = WriteZip(path, map[string][]byte{
+		"ILCD/sources/9ba3ac1e-6797-4cc0-afd5-1b8f7bf28c6a.xml": source,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r2, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r2.Close()
+	err = r2.ExtractSourceFiles("9ba3ac1e-6797-4cc0-afd5-1b8f7bf28c6a", dir)
+	if !errors.Is(err, ErrEntryNotFound) {
+		t.Fatal("expected ErrEntryNotFound, got", err)
+	}
+}
@@ -2,33 +2,121 @@ package ilcd
 
 import (
 	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
 )
 
 // Flow represents an ILCD flow data set
 type Flow struct {
-	XMLName        xml.Name           `xml:"flowDataSet"`
-	Info           *FlowInfo          `xml:"flowInformation>dataSetInformation"`
-	QRef           int                `xml:"flowInformation>quantitativeReference>referenceToReferenceFlowProperty"`
-	Type           string             `xml:"modellingAndValidation>LCIMethod>typeOfDataSet"`
-	DataEntry      *CommonDataEntry   `xml:"administrativeInformation>dataEntryBy"`
-	Publication    *CommonPublication `xml:"administrativeInformation>publicationAndOwnership"`
-	FlowProperties []FlowPropertyRef  `xml:"flowProperties>flowProperty"`
+	XMLName        xml.Name           `xml:"flowDataSet" json:"-"`
+	Info           *FlowInfo          `xml:"flowInformation>dataSetInformation" json:"info,omitempty"`
+	QRef           int                `xml:"flowInformation>quantitativeReference>referenceToReferenceFlowProperty" json:"qRef"`
+	Location       string             `xml:"flowInformation>geography>locationOfSupply,omitempty" json:"location,omitempty"`
+	Type           string             `xml:"modellingAndValidation>LCIMethod>typeOfDataSet" json:"type,omitempty"`
+	DataEntry      *CommonDataEntry   `xml:"administrativeInformation>dataEntryBy" json:"dataEntry,omitempty"`
+	Publication    *CommonPublication `xml:"administrativeInformation>publicationAndOwnership" json:"publication,omitempty"`
+	FlowProperties []FlowPropertyRef  `xml:"flowProperties>flowProperty" json:"flowProperties,omitempty"`
 }
 
 // ReferenceFlowProperty returns the reference to the reference flow property of
 // the flow.
 func (f *Flow) ReferenceFlowProperty() *FlowPropertyRef {
-	if f == nil || f.FlowProperties == nil {
+	if f == nil {
+		return nil
+	}
+	return f.FlowPropertyByID(f.QRef)
+}
+
+// FlowPropertyByID returns the flow property of the flow with the given
+// internal ID or nil if there is no such flow property.
+func (f *Flow) FlowPropertyByID(id int) *FlowPropertyRef {
+	if f == nil {
 		return nil
 	}
-	for i, ref := range f.FlowProperties {
-		if ref.ID == f.QRef {
+	for i := range f.FlowProperties {
+		if f.FlowProperties[i].ID == id {
 			return &f.FlowProperties[i]
 		}
 	}
 	return nil
 }
 
+// FlowPropertyMap returns the flow properties of the flow mapped by their
+// internal IDs. The values point to the flow properties of the flow, so that
+// changes of them are reflected in the flow. If multiple flow properties have
+// the same ID, the first of them is contained in the map.
+func (f *Flow) FlowPropertyMap() map[int]*FlowPropertyRef {
+	if f == nil {
+		return nil
+	}
+	m := make(map[int]*FlowPropertyRef, len(f.FlowProperties))
+	for i := range f.FlowProperties {
+		prop := &f.FlowProperties[i]
+		if _, ok := m[prop.ID]; !ok {
+			m[prop.ID] = prop
+		}
+	}
+	return m
+}
+
+// Compartment returns the top category (level 0) and the most specific
+// sub-compartment (the category with the highest level) of the elementary flow
+// categorization of the flow, e.g. `Emissions` and `Emissions to lower
+// stratosphere and upper troposphere`. The sub-compartment is empty if the
+// categorization has only a top category; both values are empty if the flow
+// has no elementary flow categorization.
+func (f *Flow) Compartment() (string, string) {
+	if f == nil || f.Info == nil {
+		return "", ""
+	}
+	var top, sub *Compartment
+	for i := range f.Info.Compartments {
+		c := &f.Info.Compartments[i]
+		if c.Level == 0 {
+			top = c
+		} else if sub == nil || c.Level > sub.Level {
+			sub = c
+		}
+	}
+	var topName, subName string
+	if top != nil {
+		topName = strings.TrimSpace(top.Name)
+	}
+	if sub != nil {
+		subName = strings.TrimSpace(sub.Name)
+	}
+	return topName, subName
+}
+
+// ValidateFlowProperties checks the flow properties of the flow and returns an
+// error for each problem found: the reference flow property must exist and
+// have a mean value of 1 and all conversion factors must be positive. The
+// returned errors wrap ErrInvalidFlowProperty.
+func (f *Flow) ValidateFlowProperties() []error {
+	if f == nil {
+		return nil
+	}
+	var errs []error
+	ref := f.ReferenceFlowProperty()
+	if ref == nil {
+		errs = append(errs, fmt.Errorf(
+			"%w: no reference flow property with ID %d", ErrInvalidFlowProperty, f.QRef))
+	} else if ref.Mean != 1 {
+		errs = append(errs, fmt.Errorf(
+			"%w: reference flow property %d has a mean value of %v instead of 1",
+			ErrInvalidFlowProperty, ref.ID, ref.Mean))
+	}
+	for _, prop := range f.FlowProperties {
+		if prop.Mean <= 0 {
+			errs = append(errs, fmt.Errorf(
+				"%w: flow property %d has a non-positive mean value of %v",
+				ErrInvalidFlowProperty, prop.ID, prop.Mean))
+		}
+	}
+	return errs
+}
+
 // UUID returns the UUID of the data set.
 func (f *Flow) UUID() string {
 	if f == nil || f.Info == nil {
@@ -45,6 +133,40 @@ func (f *Flow) Version() string {
 	return f.Publication.Version
 }
 
+// TimeStamp returns the time stamp of the last modification of the data set
+// as it is stored in the data entry section.
+func (f *Flow) TimeStamp() string {
+	if f == nil {
+		return ""
+	}
+	return f.DataEntry.timeStamp()
+}
+
+// ModifiedAt returns the parsed time stamp of the last modification of the
+// data set.
+func (f *Flow) ModifiedAt() (time.Time, error) {
+	return parseTimeStamp(f.TimeStamp())
+}
+
+// DataFormats returns the references to the data set formats, e.g. the ILCD
+// format, that are declared in the data entry section of the data set.
+func (f *Flow) DataFormats() []Ref {
+	if f == nil {
+		return nil
+	}
+	return f.DataEntry.dataFormats()
+}
+
+// Extensions returns the extension elements of the flow, e.g. vendor specific
+// data. These are the elements of the `other` block of the data set
+// information.
+func (f *Flow) Extensions() []ExtensionElement {
+	if f == nil || f.Info == nil {
+		return nil
+	}
+	return f.Info.Other.Elements()
+}
+
 // FlowType returns the flow type constant of the flow.
 func (f *Flow) FlowType() FlowType {
 	if f == nil {
@@ -62,36 +184,123 @@ func (f *Flow) FlowType() FlowType {
 	}
 }
 
+// IsElementary returns true if the flow is an elementary flow, i.e. a flow
+// that is exchanged with the environment.
+func (f *Flow) IsElementary() bool {
+	return f.FlowType() == ElementaryFlow
+}
+
+// IsProduct returns true if the flow is a product flow.
+func (f *Flow) IsProduct() bool {
+	return f.FlowType() == ProductFlow
+}
+
+// IsWaste returns true if the flow is a waste flow.
+func (f *Flow) IsWaste() bool {
+	return f.FlowType() == WasteFlow
+}
+
+// SynonymList returns the synonyms of the flow in the given language. In ILCD,
+// the synonyms of a language are stored in a single string separated by
+// semicolons; this function splits that string and trims the synonyms.
+func (f *Flow) SynonymList(lang string) []string {
+	if f == nil || f.Info == nil {
+		return nil
+	}
+	return splitSynonyms(f.Info.Synonyms.Get(lang))
+}
+
+// splitSynonyms splits the given semicolon separated list of synonyms.
+func splitSynonyms(s string) []string {
+	var synonyms []string
+	for _, part := range strings.Split(s, ";") {
+		if synonym := strings.TrimSpace(part); synonym != "" {
+			synonyms = append(synonyms, synonym)
+		}
+	}
+	return synonyms
+}
+
+// SearchTerms returns the searchable strings of the flow: the base names,
+// single synonyms, CAS number, and general comments in all languages. The
+// returned terms are trimmed and contain no duplicates.
+func (f *Flow) SearchTerms() []string {
+	if f == nil || f.Info == nil {
+		return nil
+	}
+	var terms []string
+	seen := make(map[string]bool)
+	add := func(term string) {
+		t := strings.TrimSpace(term)
+		if t == "" || seen[t] {
+			return
+		}
+		seen[t] = true
+		terms = append(terms, t)
+	}
+	if f.Info.Name != nil {
+		for _, item := range f.Info.Name.BaseName {
+			add(item.Value)
+		}
+	}
+	for _, item := range f.Info.Synonyms {
+		for _, synonym := range splitSynonyms(item.Value) {
+			add(synonym)
+		}
+	}
+	add(f.Info.CAS)
+	for _, item := range f.Info.Comment {
+		add(item.Value)
+	}
+	return terms
+}
+
 // FlowInfo contains the general flow information
 type FlowInfo struct {
-	UUID            string           `xml:"UUID"`
-	Name            *FlowName        `xml:"name"`
-	Synonyms        LangString       `xml:"synonyms"`
-	Classifications []Classification `xml:"classificationInformation>classification"`
-	Compartments    []Compartment    `xml:"classificationInformation>elementaryFlowCategorization>category"`
-	CAS             string           `xml:"CASNumber"`
-	Comment         LangString       `xml:"generalComment"`
+	UUID            string           `xml:"UUID" json:"uuid,omitempty"`
+	Name            *FlowName        `xml:"name" json:"name,omitempty"`
+	Synonyms        LangString       `xml:"synonyms" json:"synonyms,omitempty"`
+	Classifications []Classification `xml:"classificationInformation>classification" json:"classifications,omitempty"`
+	Compartments    []Compartment    `xml:"classificationInformation>elementaryFlowCategorization>category" json:"compartments,omitempty"`
+	CAS             string           `xml:"CASNumber" json:"cas,omitempty"`
+	Comment         LangString       `xml:"generalComment" json:"comment,omitempty"`
+	Other           *Other           `xml:"other" json:"other,omitempty"`
 }
 
 // FlowName contains the name fields of a flow.
 type FlowName struct {
-	BaseName       LangString `xml:"baseName"`
-	Treatment      LangString `xml:"treatmentStandardsRoutes"`
-	MixAndLocation LangString `xml:"mixAndLocationTypes"`
-	Properties     LangString `xml:"flowProperties"`
+	BaseName       LangString `xml:"baseName" json:"baseName,omitempty"`
+	Treatment      LangString `xml:"treatmentStandardsRoutes" json:"treatment,omitempty"`
+	MixAndLocation LangString `xml:"mixAndLocationTypes" json:"mixAndLocation,omitempty"`
+	Properties     LangString `xml:"flowProperties" json:"properties,omitempty"`
 }
 
 // FlowPropertyRef describes a flow property of a flow.
 type FlowPropertyRef struct {
-	ID           int        `xml:"dataSetInternalID,attr"`
-	FlowProperty *Ref       `xml:"referenceToFlowPropertyDataSet"`
-	Mean         float64    `xml:"meanValue"`
-	Comment      LangString `xml:"generalComment"`
+	ID             int        `xml:"dataSetInternalID,attr" json:"id"`
+	FlowProperty   *Ref       `xml:"referenceToFlowPropertyDataSet" json:"flowProperty,omitempty"`
+	Mean           float64    `xml:"meanValue" json:"mean"`
+	Min            *float64   `xml:"minimumValue,omitempty" json:"min,omitempty"`
+	Max            *float64   `xml:"maximumValue,omitempty" json:"max,omitempty"`
+	Uncertainty    string     `xml:"uncertaintyDistributionType,omitempty" json:"uncertainty,omitempty"`
+	SD95           *float64   `xml:"relativeStandardDeviation95In,omitempty" json:"sd95,omitempty"`
+	DataDerivation string     `xml:"dataDerivationTypeStatus,omitempty" json:"dataDerivation,omitempty"`
+	Source         *Ref       `xml:"referenceToDataSource,omitempty" json:"source,omitempty"`
+	Comment        LangString `xml:"generalComment" json:"comment,omitempty"`
+}
+
+// DataSource returns the reference to the data source of the flow property
+// value or nil if no data source is given.
+func (ref *FlowPropertyRef) DataSource() *Ref {
+	if ref == nil {
+		return nil
+	}
+	return ref.Source
 }
 
 // A Compartment is a category in an ILCD elementary flow categorization.
 // Note that the tag names in ILCD are elementaryFlowCategorization > category
 type Compartment struct {
-	Level int    `xml:"level,attr"`
-	Name  string `xml:",chardata"`
+	Level int    `xml:"level,attr" json:"level"`
+	Name  string `xml:",chardata" json:"name,omitempty"`
 }
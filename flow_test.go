@@ -1,6 +1,9 @@
 package ilcd
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 func TestRefFlowProperty(t *testing.T) {
 	f, _ := ReadFlowFile("sample_data/flow.xml")
@@ -32,6 +35,9 @@ func TestFlowType(t *testing.T) {
 	if f.FlowType() != ElementaryFlow {
 		t.Fatal("wrong flow type")
 	}
+	if !f.IsElementary() || f.IsProduct() || f.IsWaste() {
+		t.Fatal("wrong flow type checks")
+	}
 }
 
 func TestFlowCompartments(t *testing.T) {
@@ -39,4 +45,169 @@ func TestFlowCompartments(t *testing.T) {
 	if len(f.Info.Compartments) != 3 {
 		t.Fatal("failed to read flow compartments")
 	}
+	top, sub := f.Compartment()
+	if top != "Resources" || sub != "Renewable material resources from air" {
+		t.Fatal("unexpected compartment", top, sub)
+	}
+	var nilFlow *Flow
+	if top, sub := nilFlow.Compartment(); top != "" || sub != "" {
+		t.Fatal("expected no compartment")
+	}
+}
+
+func TestFlowPropertyRefFields(t *testing.T) {
+	data := []byte(`<flowDataSet>
+		<flowInformation>
+			<geography><locationOfSupply>GLO</locationOfSupply></geography>
+		</flowInformation>
+		<flowProperties>
+			<flowProperty dataSetInternalID="0">
+				<referenceToFlowPropertyDataSet refObjectId="93a60a56-a3c8-11da-a746-0800200b9a66"/>
+				<meanValue>1.0</meanValue>
+				<minimumValue>0.5</minimumValue>
+				<maximumValue>1.5</maximumValue>
+				<uncertaintyDistributionType>triangular</uncertaintyDistributionType>
+				<dataDerivationTypeStatus>Measured</dataDerivationTypeStatus>
+				<referenceToDataSource refObjectId="220580af-2c84-4e60-82ed-c30a1c6f63f5" type="source data set"/>
+			</flowProperty>
+		</flowProperties>
+	</flowDataSet>`)
+	f, err := ReadFlow(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Location != "GLO" {
+		t.Fatal("failed to read the location of supply")
+	}
+	ref := f.ReferenceFlowProperty()
+	if ref.Min == nil || *ref.Min != 0.5 || ref.Max == nil || *ref.Max != 1.5 {
+		t.Fatal("failed to read minimum and maximum values")
+	}
+	if ref.Uncertainty != "triangular" || ref.DataDerivation != "Measured" {
+		t.Fatal("failed to read uncertainty or data derivation")
+	}
+	if ref.Source.DataSetType() != SourceDataSet {
+		t.Fatal("failed to read the data source reference")
+	}
+	written, err := RoundTrip(data, FlowDataSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reread, _ := ReadFlow(written)
+	if !sameDataSet(f, reread) {
+		t.Fatal("the new flow fields do not survive a round trip")
+	}
+}
+
+func TestFlowSearchTerms(t *testing.T) {
+	f, _ := ReadFlow([]byte(`<flowDataSet><flowInformation><dataSetInformation>
+		<name>
+			<baseName xml:lang="en">carbon dioxide</baseName>
+			<baseName xml:lang="de">Kohlendioxid</baseName>
+		</name>
+		<synonyms xml:lang="en">CO2; carbonic acid gas</synonyms>
+		<synonyms xml:lang="de">CO2</synonyms>
+		<CASNumber>000124-38-9</CASNumber>
+		<generalComment xml:lang="en"> fossil </generalComment>
+	</dataSetInformation></flowInformation></flowDataSet>`))
+	terms := f.SearchTerms()
+	expected := []string{"carbon dioxide", "Kohlendioxid", "CO2",
+		"carbonic acid gas", "000124-38-9", "fossil"}
+	if len(terms) != len(expected) {
+		t.Fatal("unexpected search terms:", terms)
+	}
+	for i := range expected {
+		if terms[i] != expected[i] {
+			t.Fatal("unexpected search terms:", terms)
+		}
+	}
+}
+
+func TestFlowSynonymList(t *testing.T) {
+	f, _ := ReadFlow([]byte(`<flowDataSet><flowInformation><dataSetInformation>
+		<synonyms xml:lang="en">CO2; carbonic acid gas;;  dry ice ;</synonyms>
+	</dataSetInformation></flowInformation></flowDataSet>`))
+	synonyms := f.SynonymList("en")
+	if len(synonyms) != 3 || synonyms[0] != "CO2" ||
+		synonyms[1] != "carbonic acid gas" || synonyms[2] != "dry ice" {
+		t.Fatal("unexpected synonyms:", synonyms)
+	}
+	if f.SynonymList("de") != nil {
+		t.Fatal("expected no German synonyms")
+	}
+}
+
+func TestFlowPropertyDataSource(t *testing.T) {
+	f, _ := ReadFlow([]byte(`<flowDataSet><flowProperties>
+		<flowProperty dataSetInternalID="0">
+			<meanValue>1.0</meanValue>
+			<referenceToDataSource type="source data set" refObjectId="2c699413-f88b-4cb5-a56d-98cb4068472f"/>
+		</flowProperty>
+		<flowProperty dataSetInternalID="1">
+			<meanValue>2.0</meanValue>
+		</flowProperty>
+	</flowProperties></flowDataSet>`))
+	if len(f.FlowProperties) != 2 {
+		t.Fatal("expected 2 flow properties")
+	}
+	source := f.FlowProperties[0].DataSource()
+	if source == nil || source.UUID != "2c699413-f88b-4cb5-a56d-98cb4068472f" {
+		t.Fatal("failed to read data source of flow property", source)
+	}
+	if f.FlowProperties[1].DataSource() != nil {
+		t.Fatal("the second flow property has no data source")
+	}
+	var ref *FlowPropertyRef
+	if ref.DataSource() != nil {
+		t.Fatal("expected nil for nil flow property")
+	}
+}
+
+func TestFlowPropertyByID(t *testing.T) {
+	ref := &Ref{UUID: "93a60a56-a3c8-11da-a746-0800200b9a66"}
+	flow := NewFlow("08a91e70-3ddc-11dd-923d-0050c2490048").
+		AddFlowProperty(0, ref, 1).
+		AddFlowProperty(3, ref, 2.5).
+		SetReferenceProperty(3).
+		Build()
+	if p := flow.FlowPropertyByID(3); p == nil || p.Mean != 2.5 || p != flow.ReferenceFlowProperty() {
+		t.Fatal("failed to get flow property by ID")
+	}
+	if flow.FlowPropertyByID(1) != nil {
+		t.Fatal("there is no flow property with ID 1")
+	}
+	m := flow.FlowPropertyMap()
+	if len(m) != 2 || m[0].Mean != 1 || m[3] != flow.FlowPropertyByID(3) {
+		t.Fatal("unexpected flow property map", m)
+	}
+	var nilFlow *Flow
+	if nilFlow.FlowPropertyByID(0) != nil || nilFlow.FlowPropertyMap() != nil {
+		t.Fatal("expected nil for nil flow")
+	}
+}
+
+func TestValidateFlowProperties(t *testing.T) {
+	flow, _ := ReadFlowFile("sample_data/flow.xml")
+	if errs := flow.ValidateFlowProperties(); len(errs) != 0 {
+		t.Fatal("unexpected errors", errs)
+	}
+	ref := &Ref{UUID: "93a60a56-a3c8-11da-a746-0800200b9a66"}
+	flow = NewFlow("08a91e70-3ddc-11dd-923d-0050c2490048").
+		AddFlowProperty(0, ref, 2).
+		AddFlowProperty(1, ref, 0).
+		SetReferenceProperty(0).
+		Build()
+	errs := flow.ValidateFlowProperties()
+	if len(errs) != 2 {
+		t.Fatal("expected 2 errors, got", errs)
+	}
+	for _, err := range errs {
+		if !errors.Is(err, ErrInvalidFlowProperty) {
+			t.Fatal("unexpected error", err)
+		}
+	}
+	flow.QRef = 7
+	if errs := flow.ValidateFlowProperties(); len(errs) != 2 {
+		t.Fatal("expected 2 errors, got", errs)
+	}
 }
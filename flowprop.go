@@ -2,15 +2,16 @@ package ilcd
 
 import (
 	"encoding/xml"
+	"time"
 )
 
 // FlowProperty represents an ILCD flow property data set.
 type FlowProperty struct {
-	XMLName     xml.Name           `xml:"flowPropertyDataSet"`
-	Info        *FlowPropertyInfo  `xml:"flowPropertiesInformation>dataSetInformation"`
-	UnitGroup   *Ref               `xml:"flowPropertiesInformation>quantitativeReference>referenceToReferenceUnitGroup"`
-	DataEntry   *CommonDataEntry   `xml:"administrativeInformation>dataEntryBy"`
-	Publication *CommonPublication `xml:"administrativeInformation>publicationAndOwnership"`
+	XMLName     xml.Name           `xml:"flowPropertyDataSet" json:"-"`
+	Info        *FlowPropertyInfo  `xml:"flowPropertiesInformation>dataSetInformation" json:"info,omitempty"`
+	UnitGroup   *Ref               `xml:"flowPropertiesInformation>quantitativeReference>referenceToReferenceUnitGroup" json:"unitGroup,omitempty"`
+	DataEntry   *CommonDataEntry   `xml:"administrativeInformation>dataEntryBy" json:"dataEntry,omitempty"`
+	Publication *CommonPublication `xml:"administrativeInformation>publicationAndOwnership" json:"publication,omitempty"`
 }
 
 // UUID returns the UUID of the data set.
@@ -29,10 +30,62 @@ func (fp *FlowProperty) Version() string {
 	return fp.Publication.Version
 }
 
+// TimeStamp returns the time stamp of the last modification of the data set
+// as it is stored in the data entry section.
+func (fp *FlowProperty) TimeStamp() string {
+	if fp == nil {
+		return ""
+	}
+	return fp.DataEntry.timeStamp()
+}
+
+// ModifiedAt returns the parsed time stamp of the last modification of the
+// data set.
+func (fp *FlowProperty) ModifiedAt() (time.Time, error) {
+	return parseTimeStamp(fp.TimeStamp())
+}
+
+// DataFormats returns the references to the data set formats, e.g. the ILCD
+// format, that are declared in the data entry section of the data set.
+func (fp *FlowProperty) DataFormats() []Ref {
+	if fp == nil {
+		return nil
+	}
+	return fp.DataEntry.dataFormats()
+}
+
+// Extensions returns the extension elements of the flow property, e.g. vendor
+// specific data. These are the elements of the `other` block of the data set
+// information.
+func (fp *FlowProperty) Extensions() []ExtensionElement {
+	if fp == nil || fp.Info == nil {
+		return nil
+	}
+	return fp.Info.Other.Elements()
+}
+
+// Name returns the name of the flow property in the given language.
+func (fp *FlowProperty) Name(lang string) string {
+	if fp == nil || fp.Info == nil {
+		return ""
+	}
+	return fp.Info.Name.Get(lang)
+}
+
+// Comment returns the general comment of the flow property in the given
+// language.
+func (fp *FlowProperty) Comment(lang string) string {
+	if fp == nil || fp.Info == nil {
+		return ""
+	}
+	return fp.Info.Comment.Get(lang)
+}
+
 // FlowPropertyInfo contains the general flow property information
 type FlowPropertyInfo struct {
-	UUID            string           `xml:"UUID"`
-	Name            LangString       `xml:"name"`
-	Classifications []Classification `xml:"classificationInformation>classification"`
-	Comment         LangString       `xml:"generalComment"`
+	UUID            string           `xml:"UUID" json:"uuid,omitempty"`
+	Name            LangString       `xml:"name" json:"name,omitempty"`
+	Classifications []Classification `xml:"classificationInformation>classification" json:"classifications,omitempty"`
+	Comment         LangString       `xml:"generalComment" json:"comment,omitempty"`
+	Other           *Other           `xml:"other" json:"other,omitempty"`
 }
@@ -0,0 +1,20 @@
+package ilcd
+
+import "testing"
+
+func TestFlowPropertyInfo(t *testing.T) {
+	fp, _ := ReadFlowPropertyFile("sample_data/flowprop.xml")
+	if fp.UUID() != "93a60a56-a3c8-11da-a746-0800200b9a66" {
+		t.Fatal("wrong UUID")
+	}
+	if fp.Name("en") != "Mass" || fp.Name("de") != "Masse" {
+		t.Fatal("wrong name")
+	}
+	if fp.Comment("en") != "Reference Flow Property Data Set of the International Reference Life Cycle Data System (ILCD)." {
+		t.Fatal("wrong comment")
+	}
+	var empty *FlowProperty
+	if empty.Name("en") != "" || empty.Comment("en") != "" {
+		t.Fatal("nil flow property should have no name or comment")
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/luc-leonard/ilcd
 
-go 1.17
+go 1.20
@@ -146,3 +146,38 @@ func (ft FlowType) String() string {
 		return "Other flow"
 	}
 }
+
+// ProcessType is an enumeration type of the different ILCD process types.
+type ProcessType int
+
+// Enumeration constants for the ILCD process types.
+const (
+	UnknownProcessType ProcessType = iota
+	UnitProcessSingleOperation
+	UnitProcessBlackBox
+	LCIResult
+	PartlyTerminatedSystem
+	AvatarProcess
+)
+
+func (pt ProcessType) String() string {
+	switch pt {
+	case UnitProcessSingleOperation:
+		return "Unit process, single operation"
+	case UnitProcessBlackBox:
+		return "Unit process, black box"
+	case LCIResult:
+		return "LCI result"
+	case PartlyTerminatedSystem:
+		return "Partly terminated system"
+	case AvatarProcess:
+		return "Avatar"
+	default:
+		return "unknown?"
+	}
+}
+
+// IsUnitProcess returns true if the process type is a unit process type.
+func (pt ProcessType) IsUnitProcess() bool {
+	return pt == UnitProcessSingleOperation || pt == UnitProcessBlackBox
+}
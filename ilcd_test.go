@@ -1,6 +1,12 @@
 package ilcd
 
-import "testing"
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+)
 
 func TestCommonDataSetFields(t *testing.T) {
 	dataSets := [7]DataSet{}
@@ -15,6 +21,10 @@ func TestCommonDataSetFields(t *testing.T) {
 		if ds.UUID() == "" || ds.Version() == "" {
 			t.Fatal("UUID or version missing in", ds)
 		}
+		formats := ds.(interface{ DataFormats() []Ref }).DataFormats()
+		if len(formats) != 1 || formats[0].UUID != "a97a0155-0234-4b87-b4ce-a45da52f2a40" {
+			t.Fatal("unexpected data set formats in", ds.UUID(), formats)
+		}
 	}
 }
 
@@ -44,3 +54,134 @@ func TestType(t *testing.T) {
 		t.Fatal("The data set type for Contact should be ContactDataSet")
 	}
 }
+
+// TestMinimalDataSetAccessors checks that the accessors of the data set types
+// do not panic when optional elements are missing or the receiver is nil.
+func TestMinimalDataSetAccessors(t *testing.T) {
+	minimal := map[DataSetType]string{
+		ModelDataSet:        "<lifeCycleModelDataSet/>",
+		MethodDataSet:       "<LCIAMethodDataSet/>",
+		ProcessDataSet:      "<processDataSet/>",
+		FlowDataSet:         "<flowDataSet/>",
+		FlowPropertyDataSet: "<flowPropertyDataSet/>",
+		UnitGroupDataSet:    "<unitGroupDataSet/>",
+		SourceDataSet:       "<sourceDataSet/>",
+		ContactDataSet:      "<contactDataSet/>",
+	}
+	for dsType, data := range minimal {
+		ds := newDataSet(dsType)
+		if err := xml.Unmarshal([]byte(data), ds); err != nil {
+			t.Fatal(err)
+		}
+		callAccessors(ds)
+	}
+	callAccessors(&Model{})
+	callAccessors((*Model)(nil))
+	callAccessors((*Method)(nil))
+	callAccessors((*Process)(nil))
+	callAccessors((*Flow)(nil))
+	callAccessors((*FlowProperty)(nil))
+	callAccessors((*UnitGroup)(nil))
+	callAccessors((*Source)(nil))
+	callAccessors((*Contact)(nil))
+	RefOf(nil, "")
+}
+
+func callAccessors(ds DataSet) {
+	ds.UUID()
+	ds.Version()
+	if m, ok := ds.(interface{ ModifiedAt() (time.Time, error) }); ok {
+		m.ModifiedAt()
+	}
+	if f, ok := ds.(interface{ DataFormats() []Ref }); ok {
+		f.DataFormats()
+	}
+	if e, ok := ds.(interface{ Extensions() []ExtensionElement }); ok {
+		e.Extensions()
+	}
+	switch d := ds.(type) {
+	case *Model:
+		d.FullName("en")
+		d.FindProviders(d.RefProcess())
+	case *Process:
+		d.FullName("en")
+		d.ProcessType()
+		d.Kind()
+		d.ModellingPrinciple()
+		d.AllocationApproaches()
+		d.PrincipleDeviations("en")
+		d.ApproachDeviations("en")
+		d.ModellingConstants("en")
+		d.ConstantDeviations("en")
+		d.MethodDetails()
+		d.Extensions()
+		d.InputExchanges()
+		d.OutputExchanges()
+		d.RefFlows()
+		d.ReferenceFlowIDs()
+		d.SubLocationCodes()
+		d.Completeness()
+		d.IncludedProcesses()
+		d.ValidityPeriod()
+		d.ReferenceExchange().EPDModules()
+		d.ReferenceExchange().Mean()
+		d.ReferenceExchange().Resulting()
+		d.ReferenceExchange().DataSource()
+		d.ReferenceExchange().IsInput()
+		d.ReferenceExchange().IsOutput()
+		d.IsReferenceOutput()
+		d.QuantitativeReferenceType()
+		d.Classifications()
+		d.Classification("").GetClass(0)
+		d.DataQuality()
+		d.Parameter("")
+	case *Flow:
+		d.ReferenceFlowProperty().DataSource()
+		d.FlowPropertyByID(0)
+		d.FlowPropertyMap()
+		d.FlowType()
+		d.IsElementary()
+		d.IsProduct()
+		d.IsWaste()
+		d.Compartment()
+		d.SynonymList("en")
+	case *FlowProperty:
+		d.Name("en")
+		d.Comment("en")
+	case *UnitGroup:
+		d.ReferenceUnit()
+	case *Source:
+		d.FileURIs()
+	}
+}
+
+func TestJSONFieldNames(t *testing.T) {
+	flow, _ := ReadFlowFile("sample_data/flow.xml")
+	data, err := json.Marshal(flow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, _ := json.Marshal(flow)
+	if !bytes.Equal(data, again) {
+		t.Fatal("JSON output is not deterministic")
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m["XMLName"]; ok {
+		t.Fatal("XMLName should not be serialized")
+	}
+	info, ok := m["info"].(map[string]interface{})
+	if !ok || info["uuid"] != "fe0acd60-3ddc-11dd-aaa4-0050c2490048" {
+		t.Fatal("unexpected JSON output", string(data))
+	}
+
+	var reread Flow
+	if err := json.Unmarshal(data, &reread); err != nil {
+		t.Fatal(err)
+	}
+	if !sameDataSet(flow, &reread) {
+		t.Fatal("JSON round trip failed")
+	}
+}
@@ -0,0 +1,76 @@
+package ilcd
+
+import (
+	"reflect"
+	"sync"
+)
+
+// maxInternLength is the maximum length of strings that are interned. Longer
+// strings like descriptions or comments are rarely repeated so that interning
+// them would only grow the pool.
+const maxInternLength = 64
+
+// stringPool holds the canonical instances of interned strings. It is safe for
+// concurrent use.
+type stringPool struct {
+	mutex   sync.Mutex
+	strings map[string]string
+}
+
+func newStringPool() *stringPool {
+	return &stringPool{strings: make(map[string]string)}
+}
+
+// intern replaces the short strings in the structure that the given value
+// points to with their canonical instances from the pool so that identical
+// strings like language codes, reference types, or unit names share the same
+// memory. It does nothing when the pool is nil.
+func (pool *stringPool) intern(v interface{}) {
+	if pool == nil || v == nil {
+		return
+	}
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	pool.internValue(reflect.ValueOf(v))
+}
+
+func (pool *stringPool) internValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			pool.internValue(v.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			pool.internValue(v.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			pool.internValue(v.Index(i))
+		}
+	case reflect.String:
+		s := v.String()
+		if s == "" || len(s) > maxInternLength || !v.CanSet() {
+			return
+		}
+		if canonical, ok := pool.strings[s]; ok {
+			v.SetString(canonical)
+			return
+		}
+		pool.strings[s] = s
+	}
+}
+
+// InternStrings enables or disables the interning of strings for the data sets
+// that are read from the package. When enabled, identical short strings like
+// language codes, type names, or unit names of all data sets read from the
+// package share the same memory. This reduces the memory usage when many data
+// sets are loaded at once but makes reading a bit slower. Disabling the
+// interning releases the pool of interned strings.
+func (r *ZipReader) InternStrings(enabled bool) {
+	if !enabled {
+		r.pool = nil
+	} else if r.pool == nil {
+		r.pool = newStringPool()
+	}
+}
@@ -0,0 +1,50 @@
+package ilcd
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestInternStrings(t *testing.T) {
+	r := openTestZip(t)
+	r.InternStrings(true)
+
+	flow, err := r.GetFlow("fe0acd60-3ddc-11dd-aaa4-0050c2490048")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fp *FlowProperty
+	r.EachFlowProperty(func(p *FlowProperty) bool {
+		fp = p
+		return false
+	})
+	a := flow.Info.Name.BaseName[0].Lang
+	b := fp.Info.Name[0].Lang
+	if a != "en" || b != "en" || unsafe.StringData(a) != unsafe.StringData(b) {
+		t.Fatal("language codes are not interned")
+	}
+	if flow.Info.UUID != "fe0acd60-3ddc-11dd-aaa4-0050c2490048" {
+		t.Fatal("interning changed the data set")
+	}
+
+	r.InternStrings(false)
+	flow, _ = r.GetFlow("fe0acd60-3ddc-11dd-aaa4-0050c2490048")
+	if unsafe.StringData(flow.Info.Name.BaseName[0].Lang) == unsafe.StringData(b) {
+		t.Fatal("strings are still interned")
+	}
+}
+
+func TestInternSkipsLongStrings(t *testing.T) {
+	pool := newStringPool()
+	long := strings.Repeat("x", maxInternLength+1)
+	pool.intern(&Ref{Type: "flow data set", URI: long})
+	if _, ok := pool.strings[long]; ok {
+		t.Fatal("long strings should not be interned")
+	}
+	if _, ok := pool.strings["flow data set"]; !ok {
+		t.Fatal("short strings should be interned")
+	}
+	var nilPool *stringPool
+	nilPool.intern(&Ref{Type: "flow data set"})
+}
@@ -0,0 +1,36 @@
+package ilcd
+
+import "fmt"
+
+// ScaledInventory returns the inventory of the process with the given UUID
+// scaled to the given amount of its reference flow. The inventory maps the
+// UUIDs of the flows to their scaled amounts, where inputs are negative and
+// outputs positive; the amounts of multiple exchanges with the same flow are
+// summed up. The resulting amounts of the exchanges are used, which are the
+// mean amounts if no resulting amounts are given. It returns an error that
+// wraps ErrNoReferenceFlow if the process has no reference flow or if the
+// amount of the reference flow is zero.
+func (r *ZipReader) ScaledInventory(processUUID string, amount float64) (map[string]float64, error) {
+	p, err := r.GetProcess(processUUID)
+	if err != nil {
+		return nil, err
+	}
+	ref := p.ReferenceExchange()
+	if ref == nil || ref.Resulting() == 0 {
+		return nil, fmt.Errorf("%w: process %s", ErrNoReferenceFlow, processUUID)
+	}
+	factor := amount / ref.Resulting()
+	inventory := make(map[string]float64)
+	for i := range p.Exchanges {
+		e := &p.Exchanges[i]
+		if e.Flow == nil {
+			continue
+		}
+		value := factor * e.Resulting()
+		if e.IsInput() {
+			value = -value
+		}
+		inventory[e.Flow.UUID] += value
+	}
+	return inventory, nil
+}
@@ -0,0 +1,56 @@
+package ilcd
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestScaledInventory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inventory.zip")
+	w, err := NewZipWriter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	product := &Ref{UUID: "7a5b4d3c-0d1e-4f2a-9b8c-6d5e4f3a2b10"}
+	fuel := &Ref{UUID: "7a5b4d3c-0d1e-4f2a-9b8c-6d5e4f3a2b11"}
+	co2 := &Ref{UUID: "7a5b4d3c-0d1e-4f2a-9b8c-6d5e4f3a2b12"}
+	p := NewProcess("3e2d1c0b-5a4f-4e3d-8c2b-1a0f9e8d7c60").
+		AddExchange(0, product, Output, 2).
+		AddExchange(1, fuel, Input, 4).
+		AddExchange(2, co2, Output, 1).
+		AddExchange(3, co2, Output, 0.5).
+		SetReferenceExchange(0).
+		Build()
+	noRef := NewProcess("3e2d1c0b-5a4f-4e3d-8c2b-1a0f9e8d7c61").
+		AddExchange(0, product, Output, 2).
+		Build()
+	for _, ds := range []DataSet{p, noRef} {
+		if err := w.WriteDataSet(ds); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	inventory, err := r.ScaledInventory(p.UUID(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inventory) != 3 || inventory[product.UUID] != 10 ||
+		inventory[fuel.UUID] != -20 || inventory[co2.UUID] != 7.5 {
+		t.Fatal("unexpected inventory", inventory)
+	}
+	if _, err := r.ScaledInventory(noRef.UUID(), 10); !errors.Is(err, ErrNoReferenceFlow) {
+		t.Fatal("expected ErrNoReferenceFlow, got", err)
+	}
+	if _, err := r.ScaledInventory("3e2d1c0b-5a4f-4e3d-8c2b-1a0f9e8d7c62", 10); !errors.Is(err, ErrDataSetNotFound) {
+		t.Fatal("expected ErrDataSetNotFound, got", err)
+	}
+}
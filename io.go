@@ -1,8 +1,12 @@
 package ilcd
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/xml"
+	"io"
 	"io/ioutil"
+	"strings"
 )
 
 // ReadModelFile reads a life cycle model from the given file.
@@ -15,7 +19,7 @@ func ReadModelFile(filePath string) (*Model, error) {
 // ReadModel reads a life cycle model from the given data.
 func ReadModel(data []byte) (*Model, error) {
 	m := &Model{}
-	err := xml.Unmarshal(data, m)
+	err := unmarshalXML(data, m)
 	return m, err
 }
 
@@ -29,7 +33,7 @@ func ReadProcessFile(filePath string) (*Process, error) {
 // ReadProcess reads a process data set from the given data
 func ReadProcess(data []byte) (*Process, error) {
 	p := &Process{}
-	err := xml.Unmarshal(data, p)
+	err := unmarshalXML(data, p)
 	return p, err
 }
 
@@ -43,7 +47,7 @@ func ReadMethodFile(filePath string) (*Method, error) {
 // ReadMethod reads a LCIA method data set from the given data.
 func ReadMethod(data []byte) (*Method, error) {
 	m := &Method{}
-	err := xml.Unmarshal(data, m)
+	err := unmarshalXML(data, m)
 	return m, err
 }
 
@@ -57,7 +61,7 @@ func ReadFlowFile(filePath string) (*Flow, error) {
 // ReadFlow reads a LCIA method data set from the given data.
 func ReadFlow(data []byte) (*Flow, error) {
 	f := &Flow{}
-	err := xml.Unmarshal(data, f)
+	err := unmarshalXML(data, f)
 	return f, err
 }
 
@@ -71,7 +75,7 @@ func ReadFlowPropertyFile(filePath string) (*FlowProperty, error) {
 // ReadFlowProperty reads a flow property data set from the given data.
 func ReadFlowProperty(data []byte) (*FlowProperty, error) {
 	fp := &FlowProperty{}
-	err := xml.Unmarshal(data, fp)
+	err := unmarshalXML(data, fp)
 	return fp, err
 }
 
@@ -92,7 +96,7 @@ func ReadContactFile(filePath string) (*Contact, error) {
 // ReadContact reads a contact data set from the given data
 func ReadContact(data []byte) (*Contact, error) {
 	c := &Contact{}
-	err := xml.Unmarshal(data, c)
+	err := unmarshalXML(data, c)
 	return c, err
 }
 
@@ -106,7 +110,7 @@ func ReadSourceFile(filePath string) (*Source, error) {
 // ReadSource reads a source data set from the given data
 func ReadSource(data []byte) (*Source, error) {
 	s := &Source{}
-	err := xml.Unmarshal(data, s)
+	err := unmarshalXML(data, s)
 	return s, err
 }
 
@@ -120,14 +124,168 @@ func ReadUnitGroupFile(filePath string) (*UnitGroup, error) {
 // ReadUnitGroup reads a unit group data set from the given data
 func ReadUnitGroup(data []byte) (*UnitGroup, error) {
 	ug := &UnitGroup{}
-	err := xml.Unmarshal(data, ug)
+	err := unmarshalXML(data, ug)
 	return ug, err
 }
 
+// isGzip returns true if the given data start with the magic bytes of the gzip
+// format.
+func isGzip(data []byte) bool {
+	return len(data) > 1 && data[0] == 0x1f && data[1] == 0x8b
+}
+
+// gunzip decompresses the given data if they are gzip compressed. Otherwise,
+// the data are returned as they are.
+func gunzip(data []byte) ([]byte, error) {
+	if !isGzip(data) {
+		return data, nil
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
+
+// unmarshalXML parses the given XML data, which may be gzip compressed, into
+// the given data set structure.
+func unmarshalXML(data []byte, dataSet interface{}) error {
+	data, err := gunzip(data)
+	if err != nil {
+		return err
+	}
+	return xml.Unmarshal(data, dataSet)
+}
+
 func readFile(filePath string, dataSet interface{}) error {
 	data, err := ioutil.ReadFile(filePath)
 	if err != nil {
 		return err
 	}
-	return xml.Unmarshal(data, dataSet)
+	return unmarshalXML(data, dataSet)
+}
+
+// RootElement returns the local name of the root element of the given XML
+// data, e.g. `processDataSet`. Only the data up to the first start element
+// are parsed. The data may be gzip compressed.
+func RootElement(data []byte) (string, error) {
+	data, err := gunzip(data)
+	if err != nil {
+		return "", err
+	}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return "", io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return "", err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+// DataSetTypeOf determines the type of the data set in the given XML data from
+// its root element. It returns ErrUnsupportedType if the root element is not
+// the root element of an ILCD data set.
+func DataSetTypeOf(data []byte) (DataSetType, error) {
+	root, err := RootElement(data)
+	if err != nil {
+		return 0, err
+	}
+	switch root {
+	case "lifeCycleModelDataSet":
+		return ModelDataSet, nil
+	case "LCIAMethodDataSet":
+		return MethodDataSet, nil
+	case "processDataSet":
+		return ProcessDataSet, nil
+	case "flowDataSet":
+		return FlowDataSet, nil
+	case "flowPropertyDataSet":
+		return FlowPropertyDataSet, nil
+	case "unitGroupDataSet":
+		return UnitGroupDataSet, nil
+	case "sourceDataSet":
+		return SourceDataSet, nil
+	case "contactDataSet":
+		return ContactDataSet, nil
+	default:
+		return 0, ErrUnsupportedType
+	}
+}
+
+// Peek returns the type, UUID, and name of the data set in the given XML data
+// without reading the complete data set. Only the data up to the end of the
+// `dataSetInformation` section are parsed, which makes it much faster than a
+// full unmarshalling for previews. The name is the base name for processes,
+// flows, and models and the short name for sources and contacts. The data may
+// be gzip compressed. It returns ErrUnsupportedType if the data do not contain
+// an ILCD data set.
+func Peek(data []byte) (dsType DataSetType, uuid string, name LangString, err error) {
+	data, err = gunzip(data)
+	if err != nil {
+		return 0, "", nil, err
+	}
+	dsType, err = DataSetTypeOf(data)
+	if err != nil {
+		return 0, "", nil, err
+	}
+	var namePath string
+	switch dsType {
+	case ModelDataSet, ProcessDataSet, FlowDataSet:
+		namePath = "name/baseName"
+	case SourceDataSet, ContactDataSet:
+		namePath = "shortName"
+	default:
+		namePath = "name"
+	}
+
+	// the path of the current element within the data set information
+	var path []string
+	inInfo := false
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return dsType, uuid, name, nil
+		}
+		if err != nil {
+			return dsType, uuid, name, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if !inInfo {
+				inInfo = t.Name.Local == "dataSetInformation"
+				continue
+			}
+			path = append(path, t.Name.Local)
+			current := strings.Join(path, "/")
+			if current != "UUID" && current != namePath {
+				continue
+			}
+			var item LangStringItem
+			if err := decoder.DecodeElement(&item, &t); err != nil {
+				return dsType, uuid, name, err
+			}
+			path = path[:len(path)-1]
+			if current == "UUID" {
+				uuid = strings.TrimSpace(item.Value)
+			} else {
+				name = append(name, item)
+			}
+		case xml.EndElement:
+			if !inInfo {
+				continue
+			}
+			if len(path) == 0 {
+				return dsType, uuid, name, nil
+			}
+			path = path[:len(path)-1]
+		}
+	}
 }
@@ -0,0 +1,138 @@
+package ilcd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestRootElement(t *testing.T) {
+	root, err := RootElement([]byte(`<?xml version="1.0"?>
+		<!-- a comment -->
+		<p:processDataSet xmlns:p="http://lca.jrc.it/ILCD/Process"><broken`))
+	if err != nil || root != "processDataSet" {
+		t.Fatal("unexpected root element", root, err)
+	}
+	if _, err := RootElement([]byte("  ")); err == nil {
+		t.Fatal("expected an error for empty data")
+	}
+}
+
+func TestDataSetTypeOf(t *testing.T) {
+	files := map[string]DataSetType{
+		"sample_data/contact.xml":   ContactDataSet,
+		"sample_data/flow.xml":      FlowDataSet,
+		"sample_data/flowprop.xml":  FlowPropertyDataSet,
+		"sample_data/method.xml":    MethodDataSet,
+		"sample_data/process.xml":   ProcessDataSet,
+		"sample_data/source.xml":    SourceDataSet,
+		"sample_data/unitgroup.xml": UnitGroupDataSet,
+	}
+	for file, expected := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dsType, err := DataSetTypeOf(data)
+		if err != nil || dsType != expected {
+			t.Fatal("unexpected type of", file, dsType, err)
+		}
+	}
+	if _, err := DataSetTypeOf([]byte("<html/>")); !errors.Is(err, ErrUnsupportedType) {
+		t.Fatal("expected ErrUnsupportedType, got", err)
+	}
+}
+
+func TestPeek(t *testing.T) {
+	files := []string{
+		"sample_data/contact.xml",
+		"sample_data/flow.xml",
+		"sample_data/flowprop.xml",
+		"sample_data/method.xml",
+		"sample_data/process.xml",
+		"sample_data/source.xml",
+		"sample_data/unitgroup.xml",
+	}
+	for _, file := range files {
+		data := mustRead(t, file)
+		dsType, err := DataSetTypeOf(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ds := newDataSet(dsType)
+		if err := unmarshalXML(data, ds); err != nil {
+			t.Fatal(err)
+		}
+		dsType, uuid, name, err := Peek(gzipData(t, data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := nameOf(ds)
+		if dsType != Type(ds) || uuid != ds.UUID() || len(name) == 0 ||
+			len(name) != len(expected) || name[0] != expected[0] {
+			t.Fatal("unexpected peek result for", file, dsType, uuid, name)
+		}
+	}
+	if _, _, _, err := Peek([]byte("<html/>")); !errors.Is(err, ErrUnsupportedType) {
+		t.Fatal("expected ErrUnsupportedType, got", err)
+	}
+}
+
+func gzipData(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadGzip(t *testing.T) {
+	data, err := ioutil.ReadFile("sample_data/flow.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	compressed := gzipData(t, data)
+	flow, err := ReadFlow(compressed)
+	if err != nil || flow.UUID() != "fe0acd60-3ddc-11dd-aaa4-0050c2490048" {
+		t.Fatal("failed to read compressed flow", err)
+	}
+	if dsType, err := DataSetTypeOf(compressed); err != nil || dsType != FlowDataSet {
+		t.Fatal("failed to detect type of compressed flow", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "gzip.zip")
+	err = WriteZip(path, map[string][]byte{
+		"ILCD/flows/fe0acd60-3ddc-11dd-aaa4-0050c2490048.xml.gz": compressed,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	raw, err := r.GetFlowData("fe0acd60-3ddc-11dd-aaa4-0050c2490048")
+	if err != nil || !bytes.Equal(raw, data) {
+		t.Fatal("failed to get decompressed flow data", err)
+	}
+	if _, err := r.GetFlow("fe0acd60-3ddc-11dd-aaa4-0050c2490048"); err != nil {
+		t.Fatal(err)
+	}
+	count := 0
+	err = r.EachFlowData(func(uuid string, d []byte) bool {
+		count++
+		return bytes.Equal(d, data)
+	})
+	if err != nil || count != 1 {
+		t.Fatal("failed to iterate over compressed flows", err)
+	}
+}
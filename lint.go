@@ -0,0 +1,189 @@
+package ilcd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Severity is the severity of an issue in a lint report.
+type Severity int
+
+// The possible severities of lint issues.
+const (
+	// LintInfo marks issues that are just noteworthy, e.g. data sets that are
+	// not used by other data sets in the package.
+	LintInfo Severity = iota
+	// LintWarning marks issues that may be intended but often indicate a
+	// problem, e.g. references to data sets that are not in the package.
+	LintWarning
+	// LintError marks issues that violate the ILCD format.
+	LintError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case LintInfo:
+		return "info"
+	case LintWarning:
+		return "warning"
+	case LintError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText writes the severity as text, e.g. in JSON reports.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// LintIssue is an issue that was found in a data set of a package.
+type LintIssue struct {
+	Severity Severity `json:"severity"`
+	Path     string   `json:"path"`
+	Message  string   `json:"message"`
+}
+
+// LintReport contains the issues found by Lint.
+type LintReport struct {
+	Issues []LintIssue `json:"issues"`
+}
+
+// add adds an issue with the given severity, path, and formatted message to
+// the report.
+func (report *LintReport) add(severity Severity, path, format string, args ...interface{}) {
+	report.Issues = append(report.Issues, LintIssue{
+		Severity: severity,
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Count returns the number of issues with the given severity.
+func (report *LintReport) Count(severity Severity) int {
+	if report == nil {
+		return 0
+	}
+	n := 0
+	for _, issue := range report.Issues {
+		if issue.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+// HasErrors returns true if the report contains issues with the severity
+// LintError.
+func (report *LintReport) HasErrors() bool {
+	return report.Count(LintError) > 0
+}
+
+// Lint checks all data sets of the package and returns a report of the found
+// issues. Errors are malformed UUIDs and versions, UUIDs in entry names that do
+// not match the UUIDs of the data sets, and invalid flow properties. Warnings
+// are references to data sets that are not contained in the package (reported
+// once per referencing data set) and data sets without an English name. Only
+// English is checked as the reference language because the data set
+// structures of this package do not capture a declared reference language.
+// Flows, flow properties, unit groups, sources, and contacts that are not
+// referenced by any other data set in the package are reported as infos. The
+// issues are sorted by path. The returned error is only non-nil if the package
+// could not be read; it does not indicate issues in the data sets.
+func (r *ZipReader) Lint() (*LintReport, error) {
+	report := &LintReport{}
+	referenced := make(map[string]bool)
+	type entry struct {
+		path string
+		key  string
+	}
+	var entries []entry
+	var gerr error
+	r.EachDataSetFile(func(f *ZipFile) bool {
+		data, err := f.Read()
+		if err == nil {
+			data, err = gunzip(data)
+		}
+		if err != nil {
+			gerr = entryErr(f.Path(), err)
+			return false
+		}
+		ds := newDataSet(f.Type())
+		if err := unmarshalXML(data, ds); err != nil {
+			report.add(LintError, f.Path(), "invalid XML: %v", err)
+			return true
+		}
+		path, uuid := f.Path(), ds.UUID()
+		entries = append(entries, entry{path, lintKey(f.Type(), uuid)})
+		lintDataSet(report, path, ds)
+
+		refs, err := findRefs(data)
+		if err != nil {
+			report.add(LintError, path, "invalid XML: %v", err)
+			return true
+		}
+		seen := make(map[string]bool)
+		for i := range refs {
+			ref := &refs[i]
+			t := ref.DataSetType()
+			if t == ExternalDoc || ref.UUID == "" || strings.EqualFold(ref.UUID, uuid) {
+				continue
+			}
+			key := lintKey(t, ref.UUID)
+			referenced[key] = true
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			if !r.has(t, ref.UUID) {
+				report.add(LintWarning, path, "reference to %s %s is not in the package",
+					ref.Type, ref.UUID)
+			}
+		}
+		return true
+	})
+	if gerr != nil {
+		return nil, gerr
+	}
+
+	for _, e := range entries {
+		if !referenced[e.key] && !strings.HasPrefix(e.key, ProcessDataSet.String()) &&
+			!strings.HasPrefix(e.key, ModelDataSet.String()) &&
+			!strings.HasPrefix(e.key, MethodDataSet.String()) {
+			report.add(LintInfo, e.path, "data set is not used by other data sets")
+		}
+	}
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		return report.Issues[i].Path < report.Issues[j].Path
+	})
+	return report, nil
+}
+
+// lintDataSet adds the issues of the given data set to the report.
+func lintDataSet(report *LintReport, path string, ds DataSet) {
+	if !IsValidUUID(ds.UUID()) {
+		report.add(LintError, path, "invalid UUID %q", ds.UUID())
+	}
+	if !IsValidVersion(ds.Version()) {
+		report.add(LintError, path, "invalid version %q", ds.Version())
+	}
+	if nameUUID, _ := ParseEntryName(path); nameUUID != "" &&
+		!strings.EqualFold(nameUUID, ds.UUID()) {
+		report.add(LintError, path, "UUID in entry name does not match UUID %q", ds.UUID())
+	}
+	if nameOf(ds).Get("en") == "" {
+		report.add(LintWarning, path, "no English name")
+	}
+	if flow, ok := ds.(*Flow); ok {
+		for _, err := range flow.ValidateFlowProperties() {
+			report.add(LintError, path, "%v", err)
+		}
+	}
+}
+
+// lintKey returns the key of a data set with the given type and UUID.
+func lintKey(t DataSetType, uuid string) string {
+	return t.String() + "/" + strings.ToLower(uuid)
+}
@@ -0,0 +1,75 @@
+package ilcd
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	r := openTestZip(t)
+	report, err := r.Lint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.HasErrors() {
+		t.Fatal("unexpected errors in sample data", report.Issues)
+	}
+	if report.Count(LintWarning) == 0 {
+		t.Fatal("expected warnings for references to missing data sets")
+	}
+	if report.Count(LintInfo) != 3 {
+		t.Fatal("expected 3 unused data sets, got", report.Count(LintInfo))
+	}
+	for i := 1; i < len(report.Issues); i++ {
+		if report.Issues[i-1].Path > report.Issues[i].Path {
+			t.Fatal("issues are not sorted by path")
+		}
+	}
+}
+
+func TestLintErrors(t *testing.T) {
+	flow := NewFlow("08a91e70-3ddc-11dd-923d-0050c2490048").
+		SetBaseName("en", "carbon dioxide").
+		SetVersion("1.0").
+		AddFlowProperty(0, &Ref{UUID: "93a60a56-a3c8-11da-a746-0800200b9a66",
+			Type: "flow property data set"}, 2).
+		Build()
+	data, err := xml.Marshal(flow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "lint.zip")
+	err = WriteZip(path, map[string][]byte{
+		"ILCD/flows/fe0acd60-3ddc-11dd-aaa4-0050c2490048.xml": data,
+		"ILCD/processes/broken.xml":                           []byte("<processDataSet>"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	report, err := r.Lint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// invalid XML, version, name mismatch, and reference flow property mean
+	if !report.HasErrors() || report.Count(LintError) != 4 {
+		t.Fatal("expected 4 errors, got", report.Issues)
+	}
+	if report.Count(LintWarning) != 1 {
+		t.Fatal("expected a warning for the missing flow property", report.Issues)
+	}
+	out, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), `"severity":"error"`) {
+		t.Fatal("severities should be written as text", string(out))
+	}
+}
@@ -2,16 +2,17 @@ package ilcd
 
 import (
 	"encoding/xml"
+	"time"
 )
 
 // Method contains the information of an ILCD LCIA method data set.
 type Method struct {
-	XMLName     xml.Name           `xml:"LCIAMethodDataSet"`
-	Info        *MethodInfo        `xml:"LCIAMethodInformation>dataSetInformation"`
-	RefQuantity *Ref               `xml:"LCIAMethodInformation>quantitativeReference>referenceQuantity"`
-	DataEntry   *CommonDataEntry   `xml:"administrativeInformation>dataEntryBy"`
-	Publication *CommonPublication `xml:"administrativeInformation>publicationAndOwnership"`
-	Factors     []ImpactFactor     `xml:"characterisationFactors>factor"`
+	XMLName     xml.Name           `xml:"LCIAMethodDataSet" json:"-"`
+	Info        *MethodInfo        `xml:"LCIAMethodInformation>dataSetInformation" json:"info,omitempty"`
+	RefQuantity *Ref               `xml:"LCIAMethodInformation>quantitativeReference>referenceQuantity" json:"refQuantity,omitempty"`
+	DataEntry   *CommonDataEntry   `xml:"administrativeInformation>dataEntryBy" json:"dataEntry,omitempty"`
+	Publication *CommonPublication `xml:"administrativeInformation>publicationAndOwnership" json:"publication,omitempty"`
+	Factors     []ImpactFactor     `xml:"characterisationFactors>factor" json:"factors,omitempty"`
 }
 
 // UUID returns the UUID of the data set.
@@ -30,22 +31,57 @@ func (m *Method) Version() string {
 	return m.Publication.Version
 }
 
+// TimeStamp returns the time stamp of the last modification of the data set
+// as it is stored in the data entry section.
+func (m *Method) TimeStamp() string {
+	if m == nil {
+		return ""
+	}
+	return m.DataEntry.timeStamp()
+}
+
+// ModifiedAt returns the parsed time stamp of the last modification of the
+// data set.
+func (m *Method) ModifiedAt() (time.Time, error) {
+	return parseTimeStamp(m.TimeStamp())
+}
+
+// DataFormats returns the references to the data set formats, e.g. the ILCD
+// format, that are declared in the data entry section of the data set.
+func (m *Method) DataFormats() []Ref {
+	if m == nil {
+		return nil
+	}
+	return m.DataEntry.dataFormats()
+}
+
+// Extensions returns the extension elements of the LCIA method, e.g. vendor
+// specific data. These are the elements of the `other` block of the data set
+// information.
+func (m *Method) Extensions() []ExtensionElement {
+	if m == nil || m.Info == nil {
+		return nil
+	}
+	return m.Info.Other.Elements()
+}
+
 // MethodInfo :<dataSetInformation>
 type MethodInfo struct {
-	UUID            string     `xml:"UUID"`
-	Name            LangString `xml:"name"`
-	Methodology     string     `xml:"methodology"`
-	ImpactCategory  string     `xml:"impactCategory"`
-	ImpactIndicator string     `xml:"impactIndicator"`
-	Comment         LangString `xml:"generalComment"`
-	ExternalDocs    []Ref      `xml:"referenceToExternalDocumentation"`
+	UUID            string     `xml:"UUID" json:"uuid,omitempty"`
+	Name            LangString `xml:"name" json:"name,omitempty"`
+	Methodology     string     `xml:"methodology" json:"methodology,omitempty"`
+	ImpactCategory  string     `xml:"impactCategory" json:"impactCategory,omitempty"`
+	ImpactIndicator string     `xml:"impactIndicator" json:"impactIndicator,omitempty"`
+	Comment         LangString `xml:"generalComment" json:"comment,omitempty"`
+	ExternalDocs    []Ref      `xml:"referenceToExternalDocumentation" json:"externalDocs,omitempty"`
+	Other           *Other     `xml:"other" json:"other,omitempty"`
 }
 
 // ImpactFactor :<characterisationFactors/factor>
 type ImpactFactor struct {
-	Flow           *Ref    `xml:"referenceToFlowDataSet"`
-	Direction      string  `xml:"exchangeDirection"`
-	MeanValue      float64 `xml:"meanValue"`
-	DataDerivation string  `xml:"dataDerivationTypeStatus"`
-	Location       string  `xml:"location"`
+	Flow           *Ref    `xml:"referenceToFlowDataSet" json:"flow,omitempty"`
+	Direction      string  `xml:"exchangeDirection" json:"direction,omitempty"`
+	MeanValue      float64 `xml:"meanValue" json:"meanValue"`
+	DataDerivation string  `xml:"dataDerivationTypeStatus" json:"dataDerivation,omitempty"`
+	Location       string  `xml:"location" json:"location,omitempty"`
 }
@@ -1,16 +1,19 @@
 package ilcd
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"time"
+)
 
 // Model represents a life cycle model data set of the extended ILCD (eILCD)
 // format.
 type Model struct {
-	XMLName     xml.Name           `xml:"lifeCycleModelDataSet"`
-	Info        *ProcessInfo       `xml:"lifeCycleModelInformation>dataSetInformation"`
-	QRef        *int               `xml:"lifeCycleModelInformation>quantitativeReference>referenceToReferenceProcess"`
-	Processes   []ProcessInstance  `xml:"lifeCycleModelInformation>technology>processes>processInstance"`
-	DataEntry   *CommonDataEntry   `xml:"administrativeInformation>dataEntryBy"`
-	Publication *CommonPublication `xml:"administrativeInformation>publicationAndOwnership"`
+	XMLName     xml.Name           `xml:"lifeCycleModelDataSet" json:"-"`
+	Info        *ProcessInfo       `xml:"lifeCycleModelInformation>dataSetInformation" json:"info,omitempty"`
+	QRef        *int               `xml:"lifeCycleModelInformation>quantitativeReference>referenceToReferenceProcess" json:"qRef,omitempty"`
+	Processes   []ProcessInstance  `xml:"lifeCycleModelInformation>technology>processes>processInstance" json:"processes,omitempty"`
+	DataEntry   *CommonDataEntry   `xml:"administrativeInformation>dataEntryBy" json:"dataEntry,omitempty"`
+	Publication *CommonPublication `xml:"administrativeInformation>publicationAndOwnership" json:"publication,omitempty"`
 }
 
 // UUID returns the UUID of the data set.
@@ -29,6 +32,40 @@ func (m *Model) Version() string {
 	return m.Publication.Version
 }
 
+// TimeStamp returns the time stamp of the last modification of the data set
+// as it is stored in the data entry section.
+func (m *Model) TimeStamp() string {
+	if m == nil {
+		return ""
+	}
+	return m.DataEntry.timeStamp()
+}
+
+// ModifiedAt returns the parsed time stamp of the last modification of the
+// data set.
+func (m *Model) ModifiedAt() (time.Time, error) {
+	return parseTimeStamp(m.TimeStamp())
+}
+
+// DataFormats returns the references to the data set formats, e.g. the ILCD
+// format, that are declared in the data entry section of the data set.
+func (m *Model) DataFormats() []Ref {
+	if m == nil {
+		return nil
+	}
+	return m.DataEntry.dataFormats()
+}
+
+// Extensions returns the extension elements of the life cycle model, e.g.
+// vendor specific data. These are the elements of the `other` block of the data
+// set information.
+func (m *Model) Extensions() []ExtensionElement {
+	if m == nil || m.Info == nil {
+		return nil
+	}
+	return m.Info.Other.Elements()
+}
+
 // FullName returns the full name of the life cylce model for the given language
 // whith all name parts concatenated to a single string.
 func (m *Model) FullName(lang string) string {
@@ -41,35 +78,35 @@ func (m *Model) FullName(lang string) string {
 // ProcessInstance describes a process reference together with its connections
 // in a life cycle model.
 type ProcessInstance struct {
-	InternalID           int                 `xml:"dataSetInternalID,attr"`
-	MultiplicationFactor float64             `xml:"multiplicationFactor,attr"`
-	Process              *Ref                `xml:"referenceToProcess"`
-	ScalingFactor        *float64            `xml:"scalingFactor,omitempty"`
-	Connections          []ProcessConnection `xml:"connections>outputExchange"`
-	Parameters           []ModelParameter    `xml:"parameters>parameter"`
+	InternalID           int                 `xml:"dataSetInternalID,attr" json:"internalID"`
+	MultiplicationFactor float64             `xml:"multiplicationFactor,attr" json:"multiplicationFactor"`
+	Process              *Ref                `xml:"referenceToProcess" json:"process,omitempty"`
+	ScalingFactor        *float64            `xml:"scalingFactor,omitempty" json:"scalingFactor,omitempty"`
+	Connections          []ProcessConnection `xml:"connections>outputExchange" json:"connections,omitempty"`
+	Parameters           []ModelParameter    `xml:"parameters>parameter" json:"parameters,omitempty"`
 }
 
 // ProcessConnection describes a connection between two processes in a life
 // cycle model.
 type ProcessConnection struct {
-	OutputFlow string           `xml:"flowUUID,attr"`
-	IsDominant *bool            `xml:"dominant,attr,omitempty"`
-	Links      []DownstreamLink `xml:"downstreamProcess"`
+	OutputFlow string           `xml:"flowUUID,attr" json:"outputFlow,omitempty"`
+	IsDominant *bool            `xml:"dominant,attr,omitempty" json:"isDominant,omitempty"`
+	Links      []DownstreamLink `xml:"downstreamProcess" json:"links,omitempty"`
 }
 
 // A DownstreamLink links the output of a process to an input of another process
 // in a life cylce model.
 type DownstreamLink struct {
-	InputFlow  string `xml:"flowUUID,attr"`
-	ProcessID  int    `xml:"id,attr"`
-	Location   string `xml:"location,attr,omitempty"`
-	IsDominant *bool  `xml:"dominant,attr,omitempty"`
+	InputFlow  string `xml:"flowUUID,attr" json:"inputFlow,omitempty"`
+	ProcessID  int    `xml:"id,attr" json:"processID"`
+	Location   string `xml:"location,attr,omitempty" json:"location,omitempty"`
+	IsDominant *bool  `xml:"dominant,attr,omitempty" json:"isDominant,omitempty"`
 }
 
 // A ModelParameter is a parameter of a process instance in a life cycle model.
 type ModelParameter struct {
-	Name  string  `xml:"name,attr"`
-	Value float64 `xml:",chardata"`
+	Name  string  `xml:"name,attr" json:"name,omitempty"`
+	Value float64 `xml:",chardata" json:"value"`
 }
 
 // RefProcess returns the reference process (instance) of the life cycle model.
@@ -0,0 +1,69 @@
+package ilcd
+
+import "fmt"
+
+// MultiReader reads data sets from multiple packages as if they were a single
+// package, e.g. a database that is distributed as a base package and one or
+// more extension packages of different vendors. The packages are searched in
+// the order in which they were added to the reader.
+type MultiReader struct {
+	readers []*ZipReader
+}
+
+// NewMultiReader creates a new reader for the given packages.
+func NewMultiReader(readers ...*ZipReader) *MultiReader {
+	return &MultiReader{readers: readers}
+}
+
+// Readers returns the package readers of the multi-reader in the order in
+// which they are searched.
+func (m *MultiReader) Readers() []*ZipReader {
+	if m == nil {
+		return nil
+	}
+	return m.readers
+}
+
+// Resolve returns the data set that is referenced by the given reference
+// together with the reader of the package that contains it, so that the
+// provenance of the data set can be tracked. If the reference has a version,
+// the first package that contains the data set with exactly that version is
+// used; otherwise, or if no package contains that version, the latest version
+// of the first package that contains the data set is returned. It returns an
+// error that wraps ErrDataSetNotFound if no package contains the data set.
+func (m *MultiReader) Resolve(ref *Ref) (DataSet, *ZipReader, error) {
+	if m == nil || ref == nil {
+		return nil, nil, ErrDataSetNotFound
+	}
+	dsType := ref.DataSetType()
+	if newDataSet(dsType) == nil {
+		return nil, nil, ErrUnsupportedType
+	}
+	if f, r := findRefIn(m.readers, ref); f != nil {
+		return m.read(r, f)
+	}
+	return nil, nil, fmt.Errorf("%w: %s %s", ErrDataSetNotFound, dsType, ref.UUID)
+}
+
+func (m *MultiReader) read(r *ZipReader, f *ZipFile) (DataSet, *ZipReader, error) {
+	ds, err := f.ReadDataSet()
+	if err != nil {
+		return nil, r, entryErr(f.Path(), err)
+	}
+	return ds, r, nil
+}
+
+// Close closes all package readers of the multi-reader. It returns the first
+// error that occurred.
+func (m *MultiReader) Close() error {
+	if m == nil {
+		return nil
+	}
+	var first error
+	for _, r := range m.readers {
+		if err := r.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
@@ -0,0 +1,53 @@
+package ilcd
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestMultiReaderResolve(t *testing.T) {
+	base := openTestZip(t)
+	path := filepath.Join(t.TempDir(), "extension.zip")
+	w, err := NewZipWriter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	uuid := "fe0acd60-3ddc-11dd-aaa4-0050c2490048"
+	extensionFlows := []DataSet{
+		NewFlow(uuid).SetVersion("04.00.000").Build(),
+		NewFlow("5d2e8b04-7f3a-4c1e-9a6b-2f0e1d3c4b5a").SetVersion("01.00.000").Build(),
+	}
+	for _, ds := range extensionFlows {
+		if err := w.WriteDataSet(ds); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	ext, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewMultiReader(base, ext)
+	defer m.Close()
+
+	ds, source, err := m.Resolve(&Ref{UUID: uuid, Type: "flow data set"})
+	if err != nil || source != base || ds.Version() != "03.00.000" {
+		t.Fatal("the flow should be resolved from the first package", err)
+	}
+	ds, source, err = m.Resolve(&Ref{UUID: uuid, Type: "flow data set", Version: "04.00.000"})
+	if err != nil || source != ext || ds.Version() != "04.00.000" {
+		t.Fatal("the flow version should be resolved from the extension package", err)
+	}
+	ds, source, err = m.Resolve(&Ref{UUID: "5d2e8b04-7f3a-4c1e-9a6b-2f0e1d3c4b5a",
+		Type: "flow data set", Version: "02.00.000"})
+	if err != nil || source != ext || ds.Version() != "01.00.000" {
+		t.Fatal("the latest version should be used when the version is not found", err)
+	}
+	_, _, err = m.Resolve(&Ref{UUID: "5d2e8b04-7f3a-4c1e-9a6b-2f0e1d3c4b5b", Type: "flow data set"})
+	if !errors.Is(err, ErrDataSetNotFound) {
+		t.Fatal("expected ErrDataSetNotFound, got", err)
+	}
+}
@@ -1,22 +1,52 @@
 package ilcd
 
 import (
+	"path"
 	"regexp"
 	"strings"
 )
 
+const uuidPattern = "[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}"
+
 var uuidRegex *regexp.Regexp
 
+var validUUIDRegex = regexp.MustCompile("^" + uuidPattern + "$")
+
 // FindUUID returns the UUID from the given path or an empty string if it cannot
 // find it.
 func FindUUID(path string) string {
 	if uuidRegex == nil {
-		pattern := "[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}"
-		uuidRegex = regexp.MustCompile(pattern)
+		uuidRegex = regexp.MustCompile(uuidPattern)
 	}
 	return uuidRegex.FindString(path)
 }
 
+// IsValidUUID returns true if the given string is a well-formed UUID in the
+// canonical 8-4-4-4-12 hex format of RFC 4122.
+func IsValidUUID(s string) bool {
+	return validUUIDRegex.MatchString(s)
+}
+
+// ParseEntryName extracts the UUID and version from the given file path or
+// zip entry name. Data sets are typically stored under names like
+// `<uuid>.xml` or `<uuid>_<version>.xml`, optionally with a `.gz` extension
+// for gzip compressed data sets. Empty strings are returned for the parts that
+// cannot be found in the name.
+func ParseEntryName(name string) (uuid, version string) {
+	base := path.Base(strings.Replace(name, "\\", "/", -1))
+	base = strings.TrimSuffix(base, ".gz")
+	base = strings.TrimSuffix(base, path.Ext(base))
+	uuid = FindUUID(base)
+	if uuid == "" {
+		return "", ""
+	}
+	rest := base[strings.Index(base, uuid)+len(uuid):]
+	if strings.HasPrefix(rest, "_") {
+		version = rest[1:]
+	}
+	return uuid, version
+}
+
 // IsModelPath returns true if the given file path or zip entry name is
 // probably a life cycle model data set (of the extended ILCD format).
 func IsModelPath(path string) bool {
@@ -24,9 +54,13 @@ func IsModelPath(path string) bool {
 }
 
 // IsMethodPath returns true if the given file path or zip entry name is
-// probably a LCIA method data set.
+// probably a LCIA method data set. Depending on the tool that created the
+// package, the folder of the LCIA methods is named `lciamethods`,
+// `LCIAMethod`, or just `methods`; the folder names are case insensitive. As
+// `methods` is a common folder name, it is only accepted directly in the root
+// or `ILCD` folder of the package.
 func IsMethodPath(path string) bool {
-	return isXMLInFolder(path, "lciamethods")
+	return isXMLInFolder(path, "lciamethod") || isXMLInTopFolder(path, "methods")
 }
 
 // IsProcessPath returns true if the given file path or zip entry name is
@@ -77,10 +111,29 @@ func IsExternalDoc(path string) bool {
 	return strings.Contains(p, "external_docs")
 }
 
+// isXMLInTopFolder returns true if the given path is the path of an XML file
+// that is located directly in a folder with exactly the given name, ignoring
+// case, where that folder is in the root or `ILCD` folder of the package.
+func isXMLInTopFolder(path, folder string) bool {
+	p := strings.ToLower(strings.Replace(path, "\\", "/", -1))
+	if !strings.HasSuffix(p, ".xml") && !strings.HasSuffix(p, ".xml.gz") {
+		return false
+	}
+	parts := strings.Split(p, "/")
+	switch len(parts) {
+	case 2:
+		return parts[0] == folder
+	case 3:
+		return parts[0] == "ilcd" && parts[1] == folder
+	default:
+		return false
+	}
+}
+
 func isXMLInFolder(path, folder string) bool {
 	p := strings.ToLower(path)
 	if !strings.Contains(p, folder) {
 		return false
 	}
-	return strings.HasSuffix(p, ".xml")
+	return strings.HasSuffix(p, ".xml") || strings.HasSuffix(p, ".xml.gz")
 }
@@ -1,6 +1,9 @@
 package ilcd
 
-import "testing"
+import (
+	"archive/zip"
+	"testing"
+)
 
 func TestFindUUID(t *testing.T) {
 	if FindUUID("no/uuid") != "" {
@@ -15,3 +18,71 @@ func TestFindUUID(t *testing.T) {
 		t.Fatal("Did not extracted UUID")
 	}
 }
+
+func TestIsValidUUID(t *testing.T) {
+	if !IsValidUUID("93a60a57-a3c8-11da-a746-0800200c9a66") {
+		t.Fatal("should be a valid UUID")
+	}
+	invalid := []string{"", "93a60a57-a3c8-11da-a746", "93a60a57a3c811daa7460800200c9a66",
+		"x93a60a57-a3c8-11da-a746-0800200c9a66", "93a60a57-a3c8-11da-a746-0800200c9a6g"}
+	for _, s := range invalid {
+		if IsValidUUID(s) {
+			t.Fatal("should not be a valid UUID:", s)
+		}
+	}
+}
+
+func TestParseEntryName(t *testing.T) {
+	uuid, version := ParseEntryName("ILCD/flows/fe0acd60-3ddc-11dd-aaa4-0050c2490048_03.00.000.xml")
+	if uuid != "fe0acd60-3ddc-11dd-aaa4-0050c2490048" || version != "03.00.000" {
+		t.Fatal("failed to parse name with version:", uuid, version)
+	}
+	uuid, version = ParseEntryName("ILCD/flows/fe0acd60-3ddc-11dd-aaa4-0050c2490048.xml")
+	if uuid != "fe0acd60-3ddc-11dd-aaa4-0050c2490048" || version != "" {
+		t.Fatal("failed to parse name without version:", uuid, version)
+	}
+	uuid, version = ParseEntryName("ILCD/flows/fe0acd60-3ddc-11dd-aaa4-0050c2490048_03.00.000.xml.gz")
+	if uuid != "fe0acd60-3ddc-11dd-aaa4-0050c2490048" || version != "03.00.000" {
+		t.Fatal("failed to parse name of compressed data set:", uuid, version)
+	}
+	if !IsFlowPath("ILCD/flows/fe0acd60-3ddc-11dd-aaa4-0050c2490048.xml.gz") {
+		t.Fatal("compressed data set not detected")
+	}
+	uuid, version = ParseEntryName("ILCD/flows/air.xml")
+	if uuid != "" || version != "" {
+		t.Fatal("there is no UUID in the name")
+	}
+}
+
+func TestIsMethodPath(t *testing.T) {
+	methods := []string{
+		"ILCD/lciamethods/992c8e8d-769a-4930-9b0f-4fa323250738.xml",
+		"ILCD/LCIAmethods/992c8e8d-769a-4930-9b0f-4fa323250738.xml",
+		"ILCD/LCIAMethod/992c8e8d-769a-4930-9b0f-4fa323250738.xml",
+		"ILCD/methods/992c8e8d-769a-4930-9b0f-4fa323250738.xml",
+		"ILCD/Methods/992c8e8d-769a-4930-9b0f-4fa323250738.xml.gz",
+		`ILCD\METHODS\992c8e8d-769a-4930-9b0f-4fa323250738.xml`,
+		"methods/992c8e8d-769a-4930-9b0f-4fa323250738.xml",
+	}
+	for _, path := range methods {
+		if !IsMethodPath(path) {
+			t.Fatal("method path not detected:", path)
+		}
+	}
+	others := []string{
+		"ILCD/processes/methods.xml",
+		"ILCD/flows/methods_of_x.xml",
+		"ILCD/methods/readme.txt",
+		"ILCD/external_docs/methods/readme.xml",
+		"ILCD/processes/methods/992c8e8d-769a-4930-9b0f-4fa323250738.xml",
+	}
+	for _, path := range others {
+		if IsMethodPath(path) {
+			t.Fatal("not a method path:", path)
+		}
+	}
+	doc := &zip.File{FileHeader: zip.FileHeader{Name: "ILCD/external_docs/methods/readme.xml"}}
+	if f := newZipFile(doc); f.Type() != ExternalDoc {
+		t.Fatal("expected an external document, got", f.Type())
+	}
+}
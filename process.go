@@ -2,19 +2,27 @@ package ilcd
 
 import (
 	"encoding/xml"
+	"sort"
 	"strings"
+	"time"
 )
 
 // Process represents an ILCD process data set
 type Process struct {
-	XMLName     xml.Name           `xml:"processDataSet"`
-	Info        *ProcessInfo       `xml:"processInformation>dataSetInformation"`
-	QRefs       []int              `xml:"processInformation>quantitativeReference>referenceToReferenceFlow"`
-	Location    *ProcessLocation   `xml:"processInformation>geography>locationOfOperationSupplyOrProduction"`
-	Parameters  []Parameter        `xml:"processInformation>mathematicalRelations>variableParameter"`
-	DataEntry   *CommonDataEntry   `xml:"administrativeInformation>dataEntryBy"`
-	Publication *CommonPublication `xml:"administrativeInformation>publicationAndOwnership"`
-	Exchanges   []Exchange         `xml:"exchanges>exchange"`
+	XMLName          xml.Name             `xml:"processDataSet" json:"-"`
+	Info             *ProcessInfo         `xml:"processInformation>dataSetInformation" json:"info,omitempty"`
+	QRef             *ProcessQRef         `xml:"processInformation>quantitativeReference" json:"qRef,omitempty"`
+	Time             *ProcessTime         `xml:"processInformation>time" json:"time,omitempty"`
+	Location         *ProcessLocation     `xml:"processInformation>geography>locationOfOperationSupplyOrProduction" json:"location,omitempty"`
+	SubLocations     []SubLocation        `xml:"processInformation>geography>subLocationOfOperationSupplyOrProduction" json:"subLocations,omitempty"`
+	Technology       *ProcessTechnology   `xml:"processInformation>technology" json:"technology,omitempty"`
+	Parameters       []Parameter          `xml:"processInformation>mathematicalRelations>variableParameter" json:"parameters,omitempty"`
+	Method           *ProcessMethod       `xml:"modellingAndValidation>LCIMethodAndAllocation" json:"method,omitempty"`
+	CompletenessInfo *ProcessCompleteness `xml:"modellingAndValidation>completeness" json:"completenessInfo,omitempty"`
+	Reviews          []Review             `xml:"modellingAndValidation>validation>review" json:"reviews,omitempty"`
+	DataEntry        *CommonDataEntry     `xml:"administrativeInformation>dataEntryBy" json:"dataEntry,omitempty"`
+	Publication      *CommonPublication   `xml:"administrativeInformation>publicationAndOwnership" json:"publication,omitempty"`
+	Exchanges        []Exchange           `xml:"exchanges>exchange" json:"exchanges,omitempty"`
 }
 
 // UUID returns the UUID of the data set.
@@ -33,8 +41,34 @@ func (p *Process) Version() string {
 	return p.Publication.Version
 }
 
-// FullName returns the full name of the process for the given language whith
-// all name parts concatenated to a single string.
+// TimeStamp returns the time stamp of the last modification of the data set
+// as it is stored in the data entry section.
+func (p *Process) TimeStamp() string {
+	if p == nil {
+		return ""
+	}
+	return p.DataEntry.timeStamp()
+}
+
+// ModifiedAt returns the parsed time stamp of the last modification of the
+// data set.
+func (p *Process) ModifiedAt() (time.Time, error) {
+	return parseTimeStamp(p.TimeStamp())
+}
+
+// DataFormats returns the references to the data set formats, e.g. the ILCD
+// format, that are declared in the data entry section of the data set.
+func (p *Process) DataFormats() []Ref {
+	if p == nil {
+		return nil
+	}
+	return p.DataEntry.dataFormats()
+}
+
+// FullName returns the full name of the process for the given language with
+// all name parts concatenated to a single string. As in the ILCD tools, the
+// parts are joined in the order base name, treatment, mix and location, and
+// functional unit properties, separated by `; `. Empty parts are skipped.
 func (p *Process) FullName(lang string) string {
 	if p == nil || p.Info == nil || p.Info.Name == nil {
 		return ""
@@ -65,22 +99,170 @@ func (name *ProcessName) concat(lang string) string {
 	return n
 }
 
+// ProcessType returns the type of the process data set as it is stated in the
+// data set, e.g. "Unit process, single operation" or "LCI result".
+func (p *Process) ProcessType() string {
+	if p == nil || p.Method == nil {
+		return ""
+	}
+	return p.Method.Type
+}
+
+// Kind returns the process type constant of the process.
+func (p *Process) Kind() ProcessType {
+	switch p.ProcessType() {
+	case "Unit process, single operation":
+		return UnitProcessSingleOperation
+	case "Unit process, black box":
+		return UnitProcessBlackBox
+	case "LCI result":
+		return LCIResult
+	case "Partly terminated system":
+		return PartlyTerminatedSystem
+	case "Avatar":
+		return AvatarProcess
+	default:
+		return UnknownProcessType
+	}
+}
+
+// ModellingPrinciple returns the LCI method principle of the process, e.g.
+// "Attributional" or "Consequential".
+func (p *Process) ModellingPrinciple() string {
+	if p == nil || p.Method == nil {
+		return ""
+	}
+	return p.Method.Principle
+}
+
+// AllocationApproaches returns the LCI method approaches of the process, e.g.
+// "Allocation - mass" or "Substitution - BAT".
+func (p *Process) AllocationApproaches() []string {
+	if p == nil || p.Method == nil {
+		return nil
+	}
+	return p.Method.Approaches
+}
+
+// PrincipleDeviations returns the description of the deviations from the LCI
+// method principle of the process in the given language.
+func (p *Process) PrincipleDeviations(lang string) string {
+	if p == nil || p.Method == nil {
+		return ""
+	}
+	return p.Method.PrincipleDeviations.Get(lang)
+}
+
+// ApproachDeviations returns the description of the deviations from the LCI
+// method approaches of the process in the given language.
+func (p *Process) ApproachDeviations(lang string) string {
+	if p == nil || p.Method == nil {
+		return ""
+	}
+	return p.Method.ApproachDeviations.Get(lang)
+}
+
+// ModellingConstants returns the description of the modelling constants of
+// the process, e.g. whether net or gross calorific values are used, in the
+// given language.
+func (p *Process) ModellingConstants(lang string) string {
+	if p == nil || p.Method == nil {
+		return ""
+	}
+	return p.Method.Constants.Get(lang)
+}
+
+// ConstantDeviations returns the description of the deviations from the
+// modelling constants of the process in the given language.
+func (p *Process) ConstantDeviations(lang string) string {
+	if p == nil || p.Method == nil {
+		return ""
+	}
+	return p.Method.ConstantDeviations.Get(lang)
+}
+
+// MethodDetails returns the references to the sources that describe the LCA
+// method of the process in detail.
+func (p *Process) MethodDetails() []Ref {
+	if p == nil || p.Method == nil {
+		return nil
+	}
+	return p.Method.Details
+}
+
+// Extensions returns the extension elements of the process, e.g. the EPD
+// scenarios or the EPD sub-type. These are the elements of the `other` blocks
+// of the data set information and of the LCI method section. The extensions
+// of the exchanges are not included.
+func (p *Process) Extensions() []ExtensionElement {
+	if p == nil {
+		return nil
+	}
+	var elems []ExtensionElement
+	if p.Info != nil {
+		elems = append(elems, p.Info.Other.Elements()...)
+	}
+	if p.Method != nil {
+		elems = append(elems, p.Method.Other.Elements()...)
+	}
+	return elems
+}
+
+// InputExchanges returns the input exchanges of the process ordered by their
+// internal IDs.
+func (p *Process) InputExchanges() []Exchange {
+	return p.exchangesOf(Input)
+}
+
+// OutputExchanges returns the output exchanges of the process ordered by
+// their internal IDs.
+func (p *Process) OutputExchanges() []Exchange {
+	return p.exchangesOf(Output)
+}
+
+func (p *Process) exchangesOf(dir Direction) []Exchange {
+	if p == nil {
+		return nil
+	}
+	var exchanges []Exchange
+	for _, e := range p.Exchanges {
+		if e.ExchangeDirection() == dir {
+			exchanges = append(exchanges, e)
+		}
+	}
+	sort.SliceStable(exchanges, func(i, j int) bool {
+		return exchanges[i].InternalID < exchanges[j].InternalID
+	})
+	return exchanges
+}
+
+// ReferenceFlowIDs returns the internal IDs of the exchanges that are defined
+// as quantitative references of the process. It replaces the former QRefs
+// field of the process; the IDs are now stored in QRef.RefFlows together with
+// the other information of the quantitative reference.
+func (p *Process) ReferenceFlowIDs() []int {
+	if p == nil || p.QRef == nil {
+		return nil
+	}
+	return p.QRef.RefFlows
+}
+
 // RefFlows returns the exchanges that are defined as quantitative refeferences
 // of the process. In most cases this should be just one exchange.
 func (p *Process) RefFlows() []*Exchange {
-	if len(p.QRefs) == 0 {
+	ids := p.ReferenceFlowIDs()
+	if len(ids) == 0 {
 		return nil
 	}
-	n := 0
 	var refs []*Exchange
-	for _, e := range p.Exchanges {
-		for _, id := range p.QRefs {
+	for i := range p.Exchanges {
+		e := &p.Exchanges[i]
+		for _, id := range ids {
 			if id != e.InternalID {
 				continue
 			}
-			refs = append(refs, &e)
-			n++
-			if n >= len(p.QRefs) {
+			refs = append(refs, e)
+			if len(refs) >= len(ids) {
 				return refs
 			}
 		}
@@ -88,50 +270,408 @@ func (p *Process) RefFlows() []*Exchange {
 	return refs
 }
 
+// ReferenceExchange returns the (first) exchange that is defined as
+// quantitative reference of the process. It returns nil if there is no such
+// exchange. The direction of the returned exchange tells whether the reference
+// flow is an input or output, e.g. waste treatment processes typically have an
+// input as reference flow. Note that the quantitative reference of a process
+// is not necessarily a flow; see QuantitativeReferenceType.
+func (p *Process) ReferenceExchange() *Exchange {
+	refs := p.RefFlows()
+	if len(refs) == 0 {
+		return nil
+	}
+	return refs[0]
+}
+
+// IsReferenceOutput returns true if the reference exchange of the process is
+// an output, e.g. the product of a production process. It returns false if the
+// reference exchange is an input, like in waste treatment processes, or if the
+// process has no reference exchange.
+func (p *Process) IsReferenceOutput() bool {
+	return p.ReferenceExchange().IsOutput()
+}
+
+// QuantitativeReferenceType returns the type of the quantitative reference of
+// the process, e.g. "Reference flow(s)", "Functional unit", "Production
+// period", or "Other parameter".
+func (p *Process) QuantitativeReferenceType() string {
+	if p == nil || p.QRef == nil {
+		return ""
+	}
+	return p.QRef.Type
+}
+
+// Classifications returns all classifications of the process, e.g. the
+// classification in the ILCD category system and additional classifications
+// in other (vendor specific) systems.
+func (p *Process) Classifications() []Classification {
+	if p == nil || p.Info == nil {
+		return nil
+	}
+	return p.Info.Classifications
+}
+
+// Classification returns the classification of the process in the
+// classification system with the given name or nil if the process is not
+// classified in that system. Note that the ILCD classification system is
+// often stated without name; use an empty string for this case.
+func (p *Process) Classification(system string) *Classification {
+	if p == nil || p.Info == nil {
+		return nil
+	}
+	for i := range p.Info.Classifications {
+		if p.Info.Classifications[i].Name == system {
+			return &p.Info.Classifications[i]
+		}
+	}
+	return nil
+}
+
+// DataQuality returns the data quality indicators of the process as a map of
+// indicator names to their values, e.g. "Precision" -> "Fair". The indicators
+// are stated in the reviews of the process. If multiple reviews rate the same
+// indicator, the value of the first review is taken.
+func (p *Process) DataQuality() map[string]string {
+	if p == nil {
+		return nil
+	}
+	m := make(map[string]string)
+	for _, review := range p.Reviews {
+		for _, dqi := range review.Indicators {
+			if _, ok := m[dqi.Name]; !ok {
+				m[dqi.Name] = dqi.Value
+			}
+		}
+	}
+	return m
+}
+
+// Parameter returns the parameter with the given name from the
+// <mathematicalRelations> section of the process or nil if there is no such
+// parameter.
+func (p *Process) Parameter(name string) *Parameter {
+	if p == nil {
+		return nil
+	}
+	for i := range p.Parameters {
+		if p.Parameters[i].Name == name {
+			return &p.Parameters[i]
+		}
+	}
+	return nil
+}
+
 // ProcessInfo contains the general process information
 type ProcessInfo struct {
-	UUID            string           `xml:"UUID"`
-	Name            *ProcessName     `xml:"name"`
-	Synonyms        LangString       `xml:"synonyms"`
-	Classifications []Classification `xml:"classificationInformation>classification"`
-	Comment         LangString       `xml:"generalComment"`
+	UUID            string           `xml:"UUID" json:"uuid,omitempty"`
+	Name            *ProcessName     `xml:"name" json:"name,omitempty"`
+	Synonyms        LangString       `xml:"synonyms" json:"synonyms,omitempty"`
+	Classifications []Classification `xml:"classificationInformation>classification" json:"classifications,omitempty"`
+	Comment         LangString       `xml:"generalComment" json:"comment,omitempty"`
+	Other           *Other           `xml:"other" json:"other,omitempty"`
 }
 
 // ProcessName contains the name fields of a process.
 type ProcessName struct {
-	BaseName       LangString `xml:"baseName"`
-	Treatment      LangString `xml:"treatmentStandardsRoutes"`
-	MixAndLocation LangString `xml:"mixAndLocationTypes"`
-	Properties     LangString `xml:"functionalUnitFlowProperties"`
+	BaseName       LangString `xml:"baseName" json:"baseName,omitempty"`
+	Treatment      LangString `xml:"treatmentStandardsRoutes" json:"treatment,omitempty"`
+	MixAndLocation LangString `xml:"mixAndLocationTypes" json:"mixAndLocation,omitempty"`
+	Properties     LangString `xml:"functionalUnitFlowProperties" json:"properties,omitempty"`
+}
+
+// ProcessQRef contains the information of the quantitative reference of a
+// process.
+type ProcessQRef struct {
+	Type           string     `xml:"type,attr" json:"type,omitempty"`
+	RefFlows       []int      `xml:"referenceToReferenceFlow" json:"refFlows,omitempty"`
+	FunctionalUnit LangString `xml:"functionalUnitOrOther" json:"functionalUnit,omitempty"`
+}
+
+// ProcessMethod contains the information of the <LCIMethodAndAllocation>
+// section of a process data set.
+type ProcessMethod struct {
+	Type                string     `xml:"typeOfDataSet" json:"type,omitempty"`
+	Principle           string     `xml:"LCIMethodPrinciple,omitempty" json:"principle,omitempty"`
+	PrincipleDeviations LangString `xml:"deviationsFromLCIMethodPrinciple" json:"principleDeviations,omitempty"`
+	Approaches          []string   `xml:"LCIMethodApproaches" json:"approaches,omitempty"`
+	ApproachDeviations  LangString `xml:"deviationsFromLCIMethodApproaches" json:"approachDeviations,omitempty"`
+	Constants           LangString `xml:"modellingConstants" json:"constants,omitempty"`
+	ConstantDeviations  LangString `xml:"deviationsFromModellingConstants" json:"constantDeviations,omitempty"`
+	Details             []Ref      `xml:"referenceToLCAMethodDetails" json:"details,omitempty"`
+	Other               *Other     `xml:"other" json:"other,omitempty"`
+}
+
+// ProcessCompleteness contains the information of the <completeness> section
+// of a process.
+type ProcessCompleteness struct {
+	ProductModel    string              `xml:"completenessProductModel,omitempty" json:"productModel,omitempty"`
+	ImpactMethods   []Ref               `xml:"referenceToSupportedImpactAssessmentMethods" json:"impactMethods,omitempty"`
+	ElementaryFlows []CompletenessEntry `xml:"completenessElementaryFlows" json:"elementaryFlows,omitempty"`
+	Other           LangString          `xml:"completenessOtherProblemField" json:"other,omitempty"`
+}
+
+// CompletenessEntry describes the completeness of the elementary flows of a
+// process for an impact type, e.g. `Climate change` and `All relevant flows
+// quantified`.
+type CompletenessEntry struct {
+	Type  string `xml:"type,attr" json:"type,omitempty"`
+	Value string `xml:"value,attr" json:"value,omitempty"`
+}
+
+// Completeness returns the completeness of the elementary flows of the
+// process for the different impact types.
+func (p *Process) Completeness() []CompletenessEntry {
+	if p == nil || p.CompletenessInfo == nil {
+		return nil
+	}
+	return p.CompletenessInfo.ElementaryFlows
+}
+
+// Review contains the information of a <review> element in the validation
+// section of a process data set.
+type Review struct {
+	Type       string                 `xml:"type,attr" json:"type,omitempty"`
+	Indicators []DataQualityIndicator `xml:"dataQualityIndicators>dataQualityIndicator" json:"indicators,omitempty"`
+	Details    LangString             `xml:"reviewDetails" json:"details,omitempty"`
+	Reviewers  []Ref                  `xml:"referenceToNameOfReviewerAndInstitution" json:"reviewers,omitempty"`
+	Report     *Ref                   `xml:"referenceToCompleteReviewReport,omitempty" json:"report,omitempty"`
+}
+
+// DataQualityIndicator is the rating of a data quality aspect of a process
+// in a review.
+type DataQualityIndicator struct {
+	Name  string `xml:"name,attr" json:"name,omitempty"`
+	Value string `xml:"value,attr" json:"value,omitempty"`
 }
 
 // ProcessLocation contains the information of a process location.
 type ProcessLocation struct {
-	Code        string     `xml:"location,attr"`
-	LatLong     string     `xml:"latitudeAndLongitude,attr"`
-	Description LangString `xml:"descriptionOfRestrictions"`
+	Code        string     `xml:"location,attr" json:"code,omitempty"`
+	LatLong     string     `xml:"latitudeAndLongitude,attr" json:"latLong,omitempty"`
+	Description LangString `xml:"descriptionOfRestrictions" json:"description,omitempty"`
+}
+
+// ProcessTime contains the information of the <time> section of a process.
+type ProcessTime struct {
+	ReferenceYear int        `xml:"referenceYear,omitempty" json:"referenceYear,omitempty"`
+	ValidUntil    int        `xml:"dataSetValidUntil,omitempty" json:"validUntil,omitempty"`
+	Description   LangString `xml:"timeRepresentativenessDescription" json:"description,omitempty"`
+}
+
+// ValidityPeriod returns the reference year of the process and the year until
+// which the process is valid. A value is 0 if it is not defined.
+func (p *Process) ValidityPeriod() (start, end int) {
+	if p == nil || p.Time == nil {
+		return 0, 0
+	}
+	return p.Time.ReferenceYear, p.Time.ValidUntil
+}
+
+// ProcessTechnology contains the information of the <technology> section of a
+// process.
+type ProcessTechnology struct {
+	Description       LangString `xml:"technologyDescriptionAndIncludedProcesses" json:"description,omitempty"`
+	IncludedProcesses []Ref      `xml:"referenceToIncludedProcesses" json:"includedProcesses,omitempty"`
+	Applicability     LangString `xml:"technologicalApplicability" json:"applicability,omitempty"`
+}
+
+// IncludedProcesses returns the references to the processes that are included
+// in the process, e.g. the unit processes that are aggregated in an LCI
+// result.
+func (p *Process) IncludedProcesses() []Ref {
+	if p == nil || p.Technology == nil {
+		return nil
+	}
+	return p.Technology.IncludedProcesses
+}
+
+// SubLocation contains the information of a sub-location of a process, e.g.
+// a region of a market or mix process.
+type SubLocation struct {
+	Code        string     `xml:"subLocation,attr" json:"code,omitempty"`
+	LatLong     string     `xml:"latitudeAndLongitude,attr,omitempty" json:"latLong,omitempty"`
+	Description LangString `xml:"descriptionOfRestrictions" json:"description,omitempty"`
+}
+
+// SubLocationCodes returns the location codes of the sub-locations of the
+// process.
+func (p *Process) SubLocationCodes() []string {
+	if p == nil {
+		return nil
+	}
+	var codes []string
+	for _, loc := range p.SubLocations {
+		if loc.Code != "" {
+			codes = append(codes, loc.Code)
+		}
+	}
+	return codes
 }
 
 // Parameter contains the information of a process parameter or variable under
 // the tag <variableParameter>
 type Parameter struct {
-	Name    string     `xml:"name,attr"`
-	Formula string     `xml:"formula,omitempty"`
-	Value   float64    `xml:"meanValue"`
-	SD95    float64    `xml:"relativeStandardDeviation95In"`
-	Comment LangString `xml:"comment"`
+	Name    string     `xml:"name,attr" json:"name,omitempty"`
+	Formula string     `xml:"formula,omitempty" json:"formula,omitempty"`
+	Value   float64    `xml:"meanValue" json:"value"`
+	SD95    float64    `xml:"relativeStandardDeviation95In" json:"sd95"`
+	Comment LangString `xml:"comment" json:"comment,omitempty"`
 }
 
 // Exchange is an input or output of an ILCD process data set. Note that an
 // exchange has a MeanAmount and ResultingAmount. Both values are the same if
 // the exchange has no reference to a variable. Otherwise the ResultingAmount
-// is calculated via the formula: ResultingAmount = MeanAmount * Variable.
+// is calculated via the formula: ResultingAmount = MeanAmount * Variable. If
+// the resulting amount is not present in the data set, the Resulting method
+// falls back to the MeanAmount.
 type Exchange struct {
-	InternalID      int     `xml:"dataSetInternalID,attr"`
-	Flow            *Ref    `xml:"referenceToFlowDataSet"`
-	Direction       string  `xml:"exchangeDirection"`
-	MeanAmount      float64 `xml:"meanAmount"`
-	Variable        string  `xml:"referenceToVariable,omitempty"`
-	ResultingAmount float64 `xml:"resultingAmount"`
-	Location        string  `xml:"location"`
+	InternalID      int         `xml:"dataSetInternalID,attr" json:"internalID"`
+	Flow            *Ref        `xml:"referenceToFlowDataSet" json:"flow,omitempty"`
+	Direction       string      `xml:"exchangeDirection" json:"direction,omitempty"`
+	MeanAmount      float64     `xml:"meanAmount" json:"meanAmount"`
+	Variable        string      `xml:"referenceToVariable,omitempty" json:"variable,omitempty"`
+	ResultingAmount float64     `xml:"resultingAmount,omitempty" json:"resultingAmount,omitempty"`
+	Location        string      `xml:"location" json:"location,omitempty"`
+	Sources         []Ref       `xml:"referencesToDataSource>referenceToDataSource" json:"sources,omitempty"`
+	EPDAmounts      []EPDAmount `xml:"other>amount,omitempty" json:"epdAmounts,omitempty"`
+
+	// true if the resulting amount was present in the XML data
+	hasResulting bool
+}
+
+// exchangeXML is the XML representation of an exchange that keeps track of
+// whether the resulting amount is present.
+type exchangeXML struct {
+	exchange
+	Resulting *float64 `xml:"resultingAmount"`
+}
+
+// exchange has the fields but not the methods of Exchange.
+type exchange Exchange
+
+// UnmarshalXML reads an exchange from XML and records whether it has a
+// resulting amount.
+func (e *Exchange) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var raw exchangeXML
+	if err := d.DecodeElement(&raw, &start); err != nil {
+		return err
+	}
+	*e = Exchange(raw.exchange)
+	if raw.Resulting != nil {
+		e.ResultingAmount = *raw.Resulting
+		e.hasResulting = true
+	}
+	return nil
+}
+
+// MarshalXML writes the exchange as XML. The resulting amount is written if
+// it was present when the exchange was read or if it is not zero.
+func (e Exchange) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	raw := exchangeXML{exchange: exchange(e)}
+	if e.hasResulting || e.ResultingAmount != 0 {
+		amount := e.ResultingAmount
+		raw.Resulting = &amount
+	}
+	return enc.EncodeElement(raw, start)
+}
+
+// Mean returns the mean amount of the exchange.
+func (e *Exchange) Mean() float64 {
+	if e == nil {
+		return 0
+	}
+	return e.MeanAmount
+}
+
+// Resulting returns the resulting amount of the exchange. If the exchange has
+// no resulting amount, the mean amount is returned. An exchange has a
+// resulting amount if it was present in the XML data or if the ResultingAmount
+// is not zero.
+func (e *Exchange) Resulting() float64 {
+	if e == nil {
+		return 0
+	}
+	if !e.hasResulting && e.ResultingAmount == 0 {
+		return e.MeanAmount
+	}
+	return e.ResultingAmount
+}
+
+// DataSource returns the reference to the first data source of the exchange
+// or nil if the exchange has no data source.
+func (e *Exchange) DataSource() *Ref {
+	if e == nil || len(e.Sources) == 0 {
+		return nil
+	}
+	return &e.Sources[0]
+}
+
+// EPDAmount is an amount of an exchange for a module of an environmental
+// product declaration (EPD), e.g. `A1-A3` or `D`. These amounts are stored as
+// extensions of the ILCD format in the EPD namespace.
+type EPDAmount struct {
+	Module   string  `xml:"http://www.iai.kit.edu/EPD/2013 module,attr" json:"module,omitempty"`
+	Scenario string  `xml:"http://www.iai.kit.edu/EPD/2013 scenario,attr,omitempty" json:"scenario,omitempty"`
+	Value    float64 `xml:",chardata" json:"value"`
+}
+
+// EPDModules returns the EPD amounts of the exchange for each module. Amounts
+// that are defined for a specific scenario are stored under the key
+// `<module>/<scenario>`.
+func (e *Exchange) EPDModules() map[string]float64 {
+	if e == nil || len(e.EPDAmounts) == 0 {
+		return nil
+	}
+	modules := make(map[string]float64, len(e.EPDAmounts))
+	for _, a := range e.EPDAmounts {
+		key := a.Module
+		if a.Scenario != "" {
+			key += "/" + a.Scenario
+		}
+		modules[key] = a.Value
+	}
+	return modules
+}
+
+// Direction is the direction of an exchange or characterisation factor as it
+// is stated in the <exchangeDirection> element.
+type Direction string
+
+// The possible values of an exchange direction.
+const (
+	Input  Direction = "Input"
+	Output Direction = "Output"
+)
+
+// IsValid returns true if the direction is Input or Output.
+func (d Direction) IsValid() bool {
+	return d == Input || d == Output
+}
+
+// ExchangeDirection returns the direction of the exchange as typed value.
+func (e *Exchange) ExchangeDirection() Direction {
+	if e == nil {
+		return ""
+	}
+	return Direction(e.Direction)
+}
+
+// IsInput returns true if the exchange is an input of the process.
+func (e *Exchange) IsInput() bool {
+	return e.ExchangeDirection() == Input
+}
+
+// IsOutput returns true if the exchange is an output of the process.
+func (e *Exchange) IsOutput() bool {
+	return e.ExchangeDirection() == Output
+}
+
+// ExchangeDirection returns the direction of the characterisation factor as
+// typed value.
+func (f *ImpactFactor) ExchangeDirection() Direction {
+	if f == nil {
+		return ""
+	}
+	return Direction(f.Direction)
 }
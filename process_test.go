@@ -1,6 +1,11 @@
 package ilcd
 
-import "testing"
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
 
 func TestProcessInfo(t *testing.T) {
 	p, _ := ReadProcessFile("sample_data/process.xml")
@@ -19,6 +24,18 @@ func TestProcessName(t *testing.T) {
 	}
 }
 
+func TestProcessFullName(t *testing.T) {
+	p, _ := ReadProcessFile("sample_data/process.xml")
+	expected := "Electricity grid mix 1kV-60kV; AC; consumption mix, at consumer; 1kV - 60kV"
+	if name := p.FullName("en"); name != expected {
+		t.Fatal("unexpected full name:", name)
+	}
+	p.Info.Name.Treatment = nil
+	if name := p.FullName("en"); name != "Electricity grid mix 1kV-60kV; consumption mix, at consumer; 1kV - 60kV" {
+		t.Fatal("empty parts should be skipped:", name)
+	}
+}
+
 func TestProcessSynonyms(t *testing.T) {
 	p, _ := ReadProcessFile("sample_data/process.xml")
 	synonyms := p.Info.Synonyms
@@ -48,3 +65,422 @@ func TestProcessParameters(t *testing.T) {
 		t.Fatal("The parameter name should be 'distance'")
 	}
 }
+
+func TestProcessParameterFields(t *testing.T) {
+	p, _ := ReadProcessFile("sample_data/process.xml")
+	param := p.Parameter("distance")
+	if param == nil {
+		t.Fatal("could not find parameter 'distance'")
+	}
+	if param.Formula != "1000" || param.Value != 1000 {
+		t.Fatal("wrong formula or mean value of parameter 'distance'")
+	}
+	if param.Comment.Get("en") != "[km] distance start - end, default = 100 km" {
+		t.Fatal("wrong parameter comment")
+	}
+	if p.Parameter("unknown") != nil {
+		t.Fatal("there should be no parameter 'unknown'")
+	}
+}
+
+func TestProcessType(t *testing.T) {
+	p, _ := ReadProcessFile("sample_data/process.xml")
+	if p.ProcessType() != "LCI result" {
+		t.Fatal("process type should be 'LCI result'")
+	}
+	if p.Kind() != LCIResult || p.Kind().IsUnitProcess() {
+		t.Fatal("process should be an LCI result")
+	}
+	var empty *Process
+	if empty.Kind() != UnknownProcessType {
+		t.Fatal("nil process should have an unknown type")
+	}
+}
+
+func TestProcessQuantitativeReference(t *testing.T) {
+	p, _ := ReadProcessFile("sample_data/process.xml")
+	if p.QuantitativeReferenceType() != "Reference flow(s)" {
+		t.Fatal("wrong type of quantitative reference")
+	}
+	ref := p.ReferenceExchange()
+	if ref == nil || ref.InternalID != 93 {
+		t.Fatal("could not find reference exchange")
+	}
+	if ids := p.ReferenceFlowIDs(); len(ids) != 1 || ids[0] != 93 {
+		t.Fatal("unexpected reference flow IDs", ids)
+	}
+	var empty *Process
+	if empty.ReferenceExchange() != nil || empty.QuantitativeReferenceType() != "" ||
+		empty.ReferenceFlowIDs() != nil {
+		t.Fatal("nil process should have no quantitative reference")
+	}
+}
+
+func TestProcessClassifications(t *testing.T) {
+	data := []byte(`<processDataSet>
+		<processInformation>
+			<dataSetInformation>
+				<classificationInformation>
+					<classification>
+						<class level="0" classId="4">Energy</class>
+					</classification>
+					<classification name="Vendor">
+						<class level="0" classId="v1">Power</class>
+						<class level="1" classId="v2">Grid</class>
+					</classification>
+				</classificationInformation>
+			</dataSetInformation>
+		</processInformation>
+	</processDataSet>`)
+	p, err := ReadProcess(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Classifications()) != 2 {
+		t.Fatal("expected two classifications")
+	}
+	if p.Classification("").GetClass(0).ID != "4" {
+		t.Fatal("wrong ILCD classification")
+	}
+	if p.Classification("Vendor").GetClass(1).Name != "Grid" {
+		t.Fatal("wrong vendor classification")
+	}
+	if p.Classification("Unknown") != nil {
+		t.Fatal("there should be no classification 'Unknown'")
+	}
+}
+
+func TestExchangeDirection(t *testing.T) {
+	p, _ := ReadProcessFile("sample_data/process.xml")
+	for i := range p.Exchanges {
+		e := &p.Exchanges[i]
+		if !e.ExchangeDirection().IsValid() || e.IsInput() == e.IsOutput() {
+			t.Fatal("invalid direction of exchange", e.InternalID)
+		}
+	}
+	if !p.Exchanges[0].IsInput() {
+		t.Fatal("the first exchange should be an input")
+	}
+	if (*Exchange)(nil).ExchangeDirection() != "" || (*ImpactFactor)(nil).ExchangeDirection() != "" {
+		t.Fatal("nil exchanges and factors should have no direction")
+	}
+	if !p.ReferenceExchange().IsOutput() || !p.IsReferenceOutput() {
+		t.Fatal("the reference exchange should be an output")
+	}
+	waste := NewProcess("c93541fe-0b28-40b8-a890-9948e9f1d41f").
+		AddExchange(0, &Ref{UUID: "fe0acd60-3ddc-11dd-aaa4-0050c2490048"}, Input, 1).
+		SetReferenceExchange(0).
+		Build()
+	if waste.IsReferenceOutput() || !waste.ReferenceExchange().IsInput() {
+		t.Fatal("the reference exchange of the waste treatment should be an input")
+	}
+	var empty *Process
+	if empty.IsReferenceOutput() {
+		t.Fatal("nil process should have no reference output")
+	}
+	if Direction("input").IsValid() {
+		t.Fatal("directions are case sensitive")
+	}
+}
+
+func TestProcessDataQuality(t *testing.T) {
+	p, _ := ReadProcessFile("sample_data/process.xml")
+	if len(p.Reviews) != 2 || p.Reviews[0].Type != "Dependent internal review" {
+		t.Fatal("failed to read reviews")
+	}
+	dq := p.DataQuality()
+	if len(dq) != 7 {
+		t.Fatal("expected 7 data quality indicators, got", len(dq))
+	}
+	if dq["Precision"] != "Fair" || dq["Completeness"] != "Very good" {
+		t.Fatal("wrong data quality indicators", dq)
+	}
+}
+
+func TestProcessModellingPrinciple(t *testing.T) {
+	p, _ := ReadProcessFile("sample_data/process.xml")
+	if p.ModellingPrinciple() != "Attributional" {
+		t.Fatal("wrong LCI method principle")
+	}
+	approaches := p.AllocationApproaches()
+	if len(approaches) != 4 || approaches[3] != "Allocation - mass" {
+		t.Fatal("wrong LCI method approaches", approaches)
+	}
+}
+
+func TestProcessMethodDetails(t *testing.T) {
+	p, _ := ReadProcessFile("sample_data/process.xml")
+	if p.PrincipleDeviations("en") != "None" || p.ConstantDeviations("en") != "None" {
+		t.Fatal("wrong deviations from the LCI method principle or constants")
+	}
+	if !strings.HasPrefix(p.ApproachDeviations(""), "For the combined heat and power production") {
+		t.Fatal("wrong deviations from the LCI method approaches")
+	}
+	if !strings.HasPrefix(p.ModellingConstants("en"), "All data used in the calculation") {
+		t.Fatal("wrong modelling constants")
+	}
+	details := p.MethodDetails()
+	if len(details) != 1 || details[0].UUID != "f2b512cd-43b2-4260-9882-eebc06731274" ||
+		details[0].Name.Get("en") != "GaBi Modelling Principles" {
+		t.Fatal("wrong LCA method details", details)
+	}
+}
+
+func TestProcessSubLocations(t *testing.T) {
+	data := []byte(`<processDataSet>
+		<processInformation>
+			<geography>
+				<locationOfOperationSupplyOrProduction location="RER"/>
+				<subLocationOfOperationSupplyOrProduction subLocation="DE">
+					<descriptionOfRestrictions xml:lang="en">45% of the supply</descriptionOfRestrictions>
+				</subLocationOfOperationSupplyOrProduction>
+				<subLocationOfOperationSupplyOrProduction subLocation="FR"/>
+			</geography>
+		</processInformation>
+	</processDataSet>`)
+	p, err := ReadProcess(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	codes := p.SubLocationCodes()
+	if p.Location.Code != "RER" || len(codes) != 2 || codes[0] != "DE" || codes[1] != "FR" {
+		t.Fatal("failed to parse sub-locations", codes)
+	}
+	if p.SubLocations[0].Description.Get("en") != "45% of the supply" {
+		t.Fatal("failed to parse sub-location description")
+	}
+	var nilProcess *Process
+	if nilProcess.SubLocationCodes() != nil {
+		t.Fatal("expected no sub-locations")
+	}
+}
+
+func TestExchangeEPDModules(t *testing.T) {
+	data := []byte(`<processDataSet xmlns:common="http://lca.jrc.it/ILCD/Common"
+		xmlns:epd="http://www.iai.kit.edu/EPD/2013">
+		<exchanges>
+			<exchange dataSetInternalID="1">
+				<meanAmount>1.0</meanAmount>
+				<common:other>
+					<epd:amount epd:module="A1-A3">1.5</epd:amount>
+					<epd:amount epd:module="C3" epd:scenario="incineration">0.25</epd:amount>
+					<epd:amount epd:module="D">-0.5</epd:amount>
+				</common:other>
+			</exchange>
+			<exchange dataSetInternalID="2"/>
+		</exchanges>
+	</processDataSet>`)
+	p, err := ReadProcess(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	modules := p.Exchanges[0].EPDModules()
+	if len(modules) != 3 || modules["A1-A3"] != 1.5 ||
+		modules["C3/incineration"] != 0.25 || modules["D"] != -0.5 {
+		t.Fatal("failed to parse EPD modules", modules)
+	}
+	if p.Exchanges[1].EPDModules() != nil {
+		t.Fatal("expected no EPD modules")
+	}
+	out, err := RoundTrip(data, ProcessDataSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := ReadProcess(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sameDataSet(p, q) {
+		t.Fatal("EPD amounts are not preserved")
+	}
+}
+
+func TestExchangeAmounts(t *testing.T) {
+	data := []byte(`<processDataSet>
+		<exchanges>
+			<exchange dataSetInternalID="1">
+				<meanAmount>2</meanAmount>
+				<referenceToVariable>factor</referenceToVariable>
+				<resultingAmount>5</resultingAmount>
+			</exchange>
+			<exchange dataSetInternalID="2">
+				<meanAmount>3</meanAmount>
+			</exchange>
+			<exchange dataSetInternalID="3">
+				<meanAmount>4</meanAmount>
+				<resultingAmount>0</resultingAmount>
+			</exchange>
+		</exchanges>
+	</processDataSet>`)
+	p, err := ReadProcess(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check := func(p *Process) {
+		t.Helper()
+		if e := &p.Exchanges[0]; e.Mean() != 2 || e.Resulting() != 5 {
+			t.Fatal("unexpected amounts", e.Mean(), e.Resulting())
+		}
+		if e := &p.Exchanges[1]; e.Mean() != 3 || e.Resulting() != 3 || e.ResultingAmount != 0 {
+			t.Fatal("unexpected amounts", e.Mean(), e.Resulting())
+		}
+		if e := &p.Exchanges[2]; e.Mean() != 4 || e.Resulting() != 0 {
+			t.Fatal("an explicit zero should be kept", e.Mean(), e.Resulting())
+		}
+	}
+	check(p)
+	out, err := xml.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Count(out, []byte("<resultingAmount>")) != 2 {
+		t.Fatal("only present resulting amounts should be written:", string(out))
+	}
+	q, err := ReadProcess(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check(q)
+	var nilExchange *Exchange
+	if nilExchange.Mean() != 0 || nilExchange.Resulting() != 0 {
+		t.Fatal("expected zero amounts")
+	}
+}
+
+func TestProcessCompleteness(t *testing.T) {
+	p, _ := ReadProcessFile("sample_data/process.xml")
+	entries := p.Completeness()
+	if len(entries) != 1 || entries[0].Type != "Noise" || entries[0].Value != "No statement" {
+		t.Fatal("unexpected completeness entries", entries)
+	}
+	if p.CompletenessInfo.ProductModel != "All relevant flows quantified" {
+		t.Fatal("unexpected completeness of the product model")
+	}
+	var nilProcess *Process
+	if nilProcess.Completeness() != nil {
+		t.Fatal("expected no completeness entries")
+	}
+}
+
+func TestProcessIncludedProcesses(t *testing.T) {
+	p, _ := ReadProcessFile("sample_data/process.xml")
+	refs := p.IncludedProcesses()
+	if len(refs) != 10 {
+		t.Fatal("expected 10 included processes, got", len(refs))
+	}
+	if refs[0].UUID != "7a4fe9a4-582b-40e2-9ef5-6921bb893d9f" ||
+		refs[0].DataSetType() != ProcessDataSet {
+		t.Fatal("unexpected included process", refs[0])
+	}
+	if p.Technology.Description.Get("en") == "" {
+		t.Fatal("no technology description")
+	}
+}
+
+func TestProcessValidityPeriod(t *testing.T) {
+	p, _ := ReadProcessFile("sample_data/process.xml")
+	start, end := p.ValidityPeriod()
+	if start != 2008 || end != 2015 {
+		t.Fatal("unexpected validity period", start, end)
+	}
+	if p.Time.Description.Get("en") != "annual average" {
+		t.Fatal("unexpected time description")
+	}
+	var nilProcess *Process
+	if start, end := nilProcess.ValidityPeriod(); start != 0 || end != 0 {
+		t.Fatal("expected no validity period")
+	}
+}
+
+func TestExchangeDataSource(t *testing.T) {
+	data := []byte(`<processDataSet>
+		<exchanges>
+			<exchange dataSetInternalID="1">
+				<meanAmount>1</meanAmount>
+				<referencesToDataSource>
+					<referenceToDataSource type="source data set" refObjectId="220580af-2c84-4e60-82ed-c30a1c6f63f5"/>
+					<referenceToDataSource type="source data set" refObjectId="9ba3ac1e-6797-4cc0-afd5-1b8f7bf28c6a"/>
+				</referencesToDataSource>
+			</exchange>
+			<exchange dataSetInternalID="2"/>
+		</exchanges>
+	</processDataSet>`)
+	p, err := ReadProcess(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := p.Exchanges[0].DataSource()
+	if source == nil || source.UUID != "220580af-2c84-4e60-82ed-c30a1c6f63f5" ||
+		len(p.Exchanges[0].Sources) != 2 {
+		t.Fatal("failed to parse data sources of exchange")
+	}
+	if p.Exchanges[1].DataSource() != nil {
+		t.Fatal("expected no data source")
+	}
+}
+
+func TestProcessExtensions(t *testing.T) {
+	p, err := ReadProcess([]byte(`<processDataSet
+		xmlns:common="http://lca.jrc.it/ILCD/Common"
+		xmlns:epd="http://www.iai.kit.edu/EPD/2013">
+		<processInformation>
+			<dataSetInformation>
+				<UUID>c93541fe-0b28-40b8-a890-9948e9f1d41f</UUID>
+				<common:other>
+					<epd:scenarios>
+						<epd:scenario epd:name="S1" epd:default="true"/>
+					</epd:scenarios>
+				</common:other>
+			</dataSetInformation>
+		</processInformation>
+		<modellingAndValidation>
+			<LCIMethodAndAllocation>
+				<common:other>
+					<epd:subType>generic dataset</epd:subType>
+				</common:other>
+			</LCIMethodAndAllocation>
+		</modellingAndValidation>
+	</processDataSet>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exts := p.Extensions()
+	if len(exts) != 2 {
+		t.Fatal("expected 2 extension elements, got", exts)
+	}
+	if exts[0].Name() != "scenarios" || exts[0].Namespace() != epdNamespace ||
+		!strings.Contains(exts[0].InnerXML, `epd:name="S1"`) {
+		t.Fatal("unexpected scenarios extension", exts[0])
+	}
+	if exts[1].Name() != "subType" || exts[1].InnerXML != "generic dataset" {
+		t.Fatal("unexpected sub-type extension", exts[1])
+	}
+
+	sample, _ := ReadProcessFile("sample_data/process.xml")
+	if sample.Extensions() != nil {
+		t.Fatal("the sample process has no extensions")
+	}
+}
+
+func TestProcessExchangesByDirection(t *testing.T) {
+	flow := &Ref{UUID: "fe0acd60-3ddc-11dd-aaa4-0050c2490048"}
+	p := NewProcess("c93541fe-0b28-40b8-a890-9948e9f1d41f").
+		AddExchange(3, flow, Input, 1).
+		AddExchange(0, flow, Output, 2).
+		AddExchange(1, flow, Input, 3).
+		AddExchange(2, flow, Output, 4).
+		Build()
+	inputs := p.InputExchanges()
+	if len(inputs) != 2 || inputs[0].InternalID != 1 || inputs[1].InternalID != 3 {
+		t.Fatal("unexpected inputs", inputs)
+	}
+	outputs := p.OutputExchanges()
+	if len(outputs) != 2 || outputs[0].InternalID != 0 || outputs[1].InternalID != 2 {
+		t.Fatal("unexpected outputs", outputs)
+	}
+
+	sample, _ := ReadProcessFile("sample_data/process.xml")
+	if len(sample.InputExchanges())+len(sample.OutputExchanges()) != len(sample.Exchanges) {
+		t.Fatal("each exchange should be an input or output")
+	}
+}
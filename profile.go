@@ -0,0 +1,77 @@
+package ilcd
+
+import (
+	"bytes"
+	"strings"
+)
+
+// The profiles that can be detected with DetectProfile.
+const (
+	// ProfileILCD is the base ILCD format.
+	ProfileILCD = "ILCD"
+	// ProfileEF is the ILCD format as used in the Environmental Footprint (EF)
+	// data sets.
+	ProfileEF = "EF"
+	// ProfileEPD is the ILCD+EPD format of environmental product declarations.
+	ProfileEPD = "EPD"
+)
+
+// epdNamespace is the namespace of the ILCD+EPD extensions.
+const epdNamespace = "http://www.iai.kit.edu/EPD/2013"
+
+// complianceDeclarations captures the compliance systems of a data set,
+// regardless of its type.
+type complianceDeclarations struct {
+	Systems []Ref `xml:"modellingAndValidation>complianceDeclarations>compliance>referenceToComplianceSystem"`
+}
+
+// DetectProfile guesses the profile of the package from the data sets in it.
+// If a data set uses the namespace of the ILCD+EPD extensions, it returns
+// ProfileEPD. Otherwise, if a data set declares a compliance with an
+// Environmental Footprint compliance system, it returns ProfileEF. In all
+// other cases, it returns ProfileILCD. Gzip compressed data sets are
+// decompressed before they are checked.
+func (r *ZipReader) DetectProfile() (string, error) {
+	profile := ProfileILCD
+	var gerr error
+	r.EachDataSetFile(func(f *ZipFile) bool {
+		data, err := r.readData(f)
+		if err != nil {
+			gerr = err
+			return false
+		}
+		if bytes.Contains(data, []byte(epdNamespace)) {
+			profile = ProfileEPD
+			return false
+		}
+		if profile == ProfileEF {
+			return true
+		}
+		var decl complianceDeclarations
+		if err := unmarshalXML(data, &decl); err != nil {
+			gerr = entryErr(f.Path(), err)
+			return false
+		}
+		for i := range decl.Systems {
+			if isEFComplianceSystem(&decl.Systems[i]) {
+				profile = ProfileEF
+				break
+			}
+		}
+		return true
+	})
+	return profile, gerr
+}
+
+// isEFComplianceSystem returns true if the given reference points to an
+// Environmental Footprint compliance system, e.g. `EF 3.0`.
+func isEFComplianceSystem(ref *Ref) bool {
+	for _, item := range ref.Name {
+		name := strings.TrimSpace(item.Value)
+		if strings.HasPrefix(name, "EF ") ||
+			strings.Contains(strings.ToLower(name), "environmental footprint") {
+			return true
+		}
+	}
+	return false
+}
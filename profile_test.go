@@ -0,0 +1,61 @@
+package ilcd
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectProfile(t *testing.T) {
+	r := openTestZip(t)
+	profile, err := r.DetectProfile()
+	if err != nil || profile != ProfileILCD {
+		t.Fatal("expected the ILCD profile, got", profile, err)
+	}
+
+	dataSets := map[string]string{
+		ProfileEF: `<flowDataSet xmlns:common="http://lca.jrc.it/ILCD/Common">
+			<modellingAndValidation>
+				<complianceDeclarations>
+					<compliance>
+						<common:referenceToComplianceSystem type="source data set" refObjectId="d92a1a12-2545-49e2-a585-55c259997756">
+							<common:shortDescription xml:lang="en">EF 3.0 compliance</common:shortDescription>
+						</common:referenceToComplianceSystem>
+					</compliance>
+				</complianceDeclarations>
+			</modellingAndValidation>
+		</flowDataSet>`,
+		ProfileEPD: `<processDataSet xmlns:epd="http://www.iai.kit.edu/EPD/2013"/>`,
+	}
+	for expected, ds := range dataSets {
+		path := filepath.Join(t.TempDir(), "profile.zip")
+		folder := "flows"
+		if expected == ProfileEPD {
+			folder = "processes"
+		}
+		compressed, err := gzipBytes([]byte(ds))
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries := map[string][]byte{
+			".xml":    []byte(ds),
+			".xml.gz": compressed,
+		}
+		for ext, data := range entries {
+			err := WriteZip(path, map[string][]byte{
+				"ILCD/" + folder + "/08a91e70-3ddc-11dd-923d-0050c2490048" + ext: data,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, err := NewZipReader(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			profile, err := r.DetectProfile()
+			r.Close()
+			if err != nil || profile != expected {
+				t.Fatal("expected profile", expected, "for", ext, "got", profile, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package ilcd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// findRefs returns all data set references in the given XML data. These are
+// the elements with a `refObjectId` attribute, regardless of where they are
+// located in the data set.
+func findRefs(data []byte) ([]Ref, error) {
+	var refs []Ref
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return refs, nil
+		}
+		if err != nil {
+			return refs, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || !hasAttr(start, "refObjectId") {
+			continue
+		}
+		var ref Ref
+		if err := decoder.DecodeElement(&ref, &start); err != nil {
+			return refs, err
+		}
+		refs = append(refs, ref)
+	}
+}
+
+func hasAttr(start xml.StartElement, name string) bool {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ProcessSources returns the sources that are referenced in the process with
+// the given UUID, e.g. as data sources of the process or its exchanges, as
+// review reports, or as compliance systems. Each source is returned only once,
+// in the order of its first reference. Sources that are not contained in the
+// package are ignored.
+func (r *ZipReader) ProcessSources(processUUID string) ([]*Source, error) {
+	data, err := r.GetProcessData(processUUID)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := findRefs(data)
+	if err != nil {
+		return nil, err
+	}
+	var sources []*Source
+	seen := make(map[string]bool)
+	for i := range refs {
+		ref := &refs[i]
+		key := strings.ToLower(ref.UUID)
+		if ref.DataSetType() != SourceDataSet || seen[key] {
+			continue
+		}
+		seen[key] = true
+		source, err := r.GetSource(ref.UUID)
+		if errors.Is(err, ErrDataSetNotFound) {
+			continue
+		}
+		if err != nil {
+			return sources, err
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
+// FlowUsage returns references to the processes of the package that have an
+// input or output exchange with the flow of the given UUID. A process that
+// has inputs and outputs of the flow is contained in both lists. The URIs of
+// the references are the paths of the processes in the package.
+func (r *ZipReader) FlowUsage(flowUUID string) (inputs, outputs []Ref, err error) {
+	r.EachFile(func(f *ZipFile) bool {
+		if !IsProcessPath(f.Path()) {
+			return true
+		}
+		p, perr := f.ReadProcess()
+		if perr != nil {
+			err = entryErr(f.Path(), perr)
+			return false
+		}
+		isInput, isOutput := false, false
+		for i := range p.Exchanges {
+			e := &p.Exchanges[i]
+			if e.Flow == nil || !strings.EqualFold(e.Flow.UUID, flowUUID) {
+				continue
+			}
+			switch e.ExchangeDirection() {
+			case Input:
+				isInput = true
+			case Output:
+				isOutput = true
+			}
+		}
+		if isInput {
+			inputs = append(inputs, RefOf(p, f.Path()))
+		}
+		if isOutput {
+			outputs = append(outputs, RefOf(p, f.Path()))
+		}
+		return true
+	})
+	return inputs, outputs, err
+}
+
+// EachRef iterates over all data set references in the data sets of the
+// package, in the order of the entries of the package and of the references
+// within a data set, unless the given function returns false. The function is
+// called with a reference to the data set that contains the reference, where
+// the URI is the path of that data set in the package, and the reference
+// itself. It returns an error if a data set could not be read.
+func (r *ZipReader) EachRef(fn func(source Ref, target Ref) bool) error {
+	var gerr error
+	r.EachDataSetFile(func(f *ZipFile) bool {
+		data, err := r.readData(f)
+		if err != nil {
+			gerr = err
+			return false
+		}
+		ds := newDataSet(f.Type())
+		if err := unmarshalXML(data, ds); err != nil {
+			gerr = entryErr(f.Path(), err)
+			return false
+		}
+		refs, err := findRefs(data)
+		if err != nil {
+			gerr = entryErr(f.Path(), err)
+			return false
+		}
+		source := RefOf(ds, f.Path())
+		for _, target := range refs {
+			if !fn(source, target) {
+				return false
+			}
+		}
+		return true
+	})
+	return gerr
+}
@@ -0,0 +1,155 @@
+package ilcd
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindRefs(t *testing.T) {
+	data, err := ioutil.ReadFile("sample_data/process.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	refs, err := findRefs(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flows := 0
+	for _, ref := range refs {
+		if ref.UUID == "" {
+			t.Fatal("reference without UUID")
+		}
+		if ref.DataSetType() == FlowDataSet {
+			flows++
+		}
+	}
+	if flows != 514 {
+		t.Fatal("expected 514 flow references, got", flows)
+	}
+}
+
+func TestProcessSources(t *testing.T) {
+	source, err := ioutil.ReadFile("sample_data/source.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	process := []byte(`<processDataSet xmlns:common="http://lca.jrc.it/ILCD/Common">
+		<processInformation>
+			<dataSetInformation>
+				<common:UUID>c93541fe-0b28-40b8-a890-9948e9f1d41f</common:UUID>
+			</dataSetInformation>
+		</processInformation>
+		<modellingAndValidation>
+			<dataSourcesTreatmentAndRepresentativeness>
+				<referenceToDataSource type="source data set" refObjectId="220580af-2c84-4e60-82ed-c30a1c6f63f5"/>
+				<referenceToDataSource type="source data set" refObjectId="9ba3ac1e-6797-4cc0-afd5-1b8f7bf28c6a"/>
+			</dataSourcesTreatmentAndRepresentativeness>
+			<validation>
+				<review>
+					<common:referenceToCompleteReviewReport type="source data set" refObjectId="220580AF-2c84-4e60-82ed-c30a1c6f63f5"/>
+				</review>
+			</validation>
+		</modellingAndValidation>
+	</processDataSet>`)
+	path := filepath.Join(t.TempDir(), "sources.zip")
+	err = WriteZip(path, map[string][]byte{
+		"ILCD/processes/c93541fe-0b28-40b8-a890-9948e9f1d41f.xml": process,
+		"ILCD/sources/220580af-2c84-4e60-82ed-c30a1c6f63f5.xml":   source,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	sources, err := r.ProcessSources("c93541fe-0b28-40b8-a890-9948e9f1d41f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sources) != 1 || sources[0].UUID() != "220580af-2c84-4e60-82ed-c30a1c6f63f5" {
+		t.Fatal("unexpected sources", sources)
+	}
+}
+
+func TestFlowUsage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "usage.zip")
+	w, err := NewZipWriter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flow := &Ref{UUID: "fe0acd60-3ddc-11dd-aaa4-0050c2490048"}
+	other := &Ref{UUID: "08a91e70-3ddc-11dd-91d5-0050c2490048"}
+	processes := []*Process{
+		NewProcess("0c2b9d4e-4d8a-4f5a-9b3e-0d2a4c1f7e01").
+			AddExchange(0, flow, Output, 1).Build(),
+		NewProcess("0c2b9d4e-4d8a-4f5a-9b3e-0d2a4c1f7e02").
+			AddExchange(0, other, Output, 1).
+			AddExchange(1, flow, Input, 2).
+			AddExchange(2, flow, Input, 3).Build(),
+		NewProcess("0c2b9d4e-4d8a-4f5a-9b3e-0d2a4c1f7e03").
+			AddExchange(0, other, Input, 1).Build(),
+	}
+	for _, p := range processes {
+		if err := w.WriteDataSet(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	inputs, outputs, err := r.FlowUsage(flow.UUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inputs) != 1 || inputs[0].UUID != processes[1].UUID() {
+		t.Fatal("unexpected inputs", inputs)
+	}
+	if len(outputs) != 1 || outputs[0].UUID != processes[0].UUID() ||
+		outputs[0].URI == "" || outputs[0].DataSetType() != ProcessDataSet {
+		t.Fatal("unexpected outputs", outputs)
+	}
+}
+
+func TestEachRef(t *testing.T) {
+	r := openTestZip(t)
+	count, processRefs := 0, 0
+	err := r.EachRef(func(source, target Ref) bool {
+		count++
+		if source.UUID == "" || source.URI == "" || target.UUID == "" {
+			t.Fatal("invalid reference", source, target)
+		}
+		if source.UUID == "c93541fe-0b28-40b8-a890-9948e9f1d41f" {
+			if source.DataSetType() != ProcessDataSet {
+				t.Fatal("the source should be a process", source)
+			}
+			processRefs++
+		}
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := ioutil.ReadFile("sample_data/process.xml")
+	refs, _ := findRefs(data)
+	if processRefs != len(refs) || count <= processRefs {
+		t.Fatal("unexpected number of references", count, processRefs)
+	}
+
+	count = 0
+	err = r.EachRef(func(source, target Ref) bool {
+		count++
+		return false
+	})
+	if err != nil || count != 1 {
+		t.Fatal("the iteration should stop when the function returns false")
+	}
+}
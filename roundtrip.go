@@ -0,0 +1,73 @@
+package ilcd
+
+import (
+	"encoding/xml"
+	"reflect"
+)
+
+// newDataSet creates an empty data set structure for the given type. It
+// returns nil if the type is not a data set type.
+func newDataSet(dsType DataSetType) DataSet {
+	switch dsType {
+	case ModelDataSet:
+		return &Model{}
+	case MethodDataSet:
+		return &Method{}
+	case ProcessDataSet:
+		return &Process{}
+	case FlowDataSet:
+		return &Flow{}
+	case FlowPropertyDataSet:
+		return &FlowProperty{}
+	case UnitGroupDataSet:
+		return &UnitGroup{}
+	case SourceDataSet:
+		return &Source{}
+	case ContactDataSet:
+		return &Contact{}
+	default:
+		return nil
+	}
+}
+
+// RoundTrip reads the given data into the data set structure of the given type
+// and writes this structure back to XML. Everything that is not captured by
+// the data set structures of this package is lost in the result. Thus, reading
+// the result of a round trip again should give the same data set structure as
+// reading the original data.
+func RoundTrip(data []byte, dataSetType DataSetType) ([]byte, error) {
+	ds := newDataSet(dataSetType)
+	if ds == nil {
+		return nil, ErrUnsupportedType
+	}
+	if err := xml.Unmarshal(data, ds); err != nil {
+		return nil, err
+	}
+	return xml.Marshal(ds)
+}
+
+// sameDataSet returns true if both data sets have the same type and contain
+// the same values. The XML name of the root element is ignored in this
+// comparison as the namespace of the root element is not written when a data
+// set structure is marshalled.
+func sameDataSet(a, b DataSet) bool {
+	if a == nil || b == nil || Type(a) != Type(b) {
+		return a == nil && b == nil
+	}
+	return reflect.DeepEqual(withoutXMLName(a), withoutXMLName(b))
+}
+
+// withoutXMLName returns a copy of the structure the given data set points to
+// with a cleared XMLName field.
+func withoutXMLName(ds DataSet) interface{} {
+	val := reflect.ValueOf(ds)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return ds
+	}
+	c := reflect.New(val.Elem().Type()).Elem()
+	c.Set(val.Elem())
+	if name := c.FieldByName("XMLName"); name.IsValid() {
+		name.Set(reflect.Zero(name.Type()))
+	}
+	return c.Interface()
+}
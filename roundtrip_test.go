@@ -0,0 +1,46 @@
+package ilcd
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	files := map[string]DataSetType{
+		"sample_data/contact.xml":   ContactDataSet,
+		"sample_data/flow.xml":      FlowDataSet,
+		"sample_data/flowprop.xml":  FlowPropertyDataSet,
+		"sample_data/method.xml":    MethodDataSet,
+		"sample_data/process.xml":   ProcessDataSet,
+		"sample_data/source.xml":    SourceDataSet,
+		"sample_data/unitgroup.xml": UnitGroupDataSet,
+	}
+	for file, dsType := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		written, err := RoundTrip(data, dsType)
+		if err != nil {
+			t.Fatal("round trip failed for", file, err)
+		}
+		original := newDataSet(dsType)
+		if err := xml.Unmarshal(data, original); err != nil {
+			t.Fatal(err)
+		}
+		reread := newDataSet(dsType)
+		if err := xml.Unmarshal(written, reread); err != nil {
+			t.Fatal(err)
+		}
+		if !sameDataSet(original, reread) {
+			t.Fatal("round trip changed the data set of", file)
+		}
+	}
+}
+
+func TestRoundTripUnsupported(t *testing.T) {
+	if _, err := RoundTrip([]byte("<x/>"), ExternalDoc); err != ErrUnsupportedType {
+		t.Fatal("expected ErrUnsupportedType for external documents")
+	}
+}
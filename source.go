@@ -1,13 +1,16 @@
 package ilcd
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"time"
+)
 
 // Source represents an ILCD source data set
 type Source struct {
-	XMLName     xml.Name           `xml:"sourceDataSet"`
-	Info        *SourceInfo        `xml:"sourceInformation>dataSetInformation"`
-	DataEntry   *CommonDataEntry   `xml:"administrativeInformation>dataEntryBy"`
-	Publication *CommonPublication `xml:"administrativeInformation>publicationAndOwnership"`
+	XMLName     xml.Name           `xml:"sourceDataSet" json:"-"`
+	Info        *SourceInfo        `xml:"sourceInformation>dataSetInformation" json:"info,omitempty"`
+	DataEntry   *CommonDataEntry   `xml:"administrativeInformation>dataEntryBy" json:"dataEntry,omitempty"`
+	Publication *CommonPublication `xml:"administrativeInformation>publicationAndOwnership" json:"publication,omitempty"`
 }
 
 // UUID returns the UUID of the data set.
@@ -26,11 +29,67 @@ func (s *Source) Version() string {
 	return s.Publication.Version
 }
 
+// TimeStamp returns the time stamp of the last modification of the data set
+// as it is stored in the data entry section.
+func (s *Source) TimeStamp() string {
+	if s == nil {
+		return ""
+	}
+	return s.DataEntry.timeStamp()
+}
+
+// ModifiedAt returns the parsed time stamp of the last modification of the
+// data set.
+func (s *Source) ModifiedAt() (time.Time, error) {
+	return parseTimeStamp(s.TimeStamp())
+}
+
+// DataFormats returns the references to the data set formats, e.g. the ILCD
+// format, that are declared in the data entry section of the data set.
+func (s *Source) DataFormats() []Ref {
+	if s == nil {
+		return nil
+	}
+	return s.DataEntry.dataFormats()
+}
+
+// Extensions returns the extension elements of the source, e.g. vendor specific
+// data. These are the elements of the `other` block of the data set
+// information.
+func (s *Source) Extensions() []ExtensionElement {
+	if s == nil || s.Info == nil {
+		return nil
+	}
+	return s.Info.Other.Elements()
+}
+
 // SourceInfo <dataSetInformation>
 type SourceInfo struct {
-	UUID            string           `xml:"UUID"`
-	ShortName       LangString       `xml:"shortName"`
-	Classifications []Classification `xml:"classificationInformation>classification"`
-	Citation        string           `xml:"sourceCitation,omitempty"`
-	PublicationType string           `xml:"publicationType,omitempty"`
+	UUID            string           `xml:"UUID" json:"uuid,omitempty"`
+	ShortName       LangString       `xml:"shortName" json:"shortName,omitempty"`
+	Classifications []Classification `xml:"classificationInformation>classification" json:"classifications,omitempty"`
+	Citation        string           `xml:"sourceCitation,omitempty" json:"citation,omitempty"`
+	PublicationType string           `xml:"publicationType,omitempty" json:"publicationType,omitempty"`
+	DigitalFiles    []DigitalFile    `xml:"referenceToDigitalFile" json:"digitalFiles,omitempty"`
+	Other           *Other           `xml:"other" json:"other,omitempty"`
+}
+
+// DigitalFile is a reference to a file or web resource of a source, e.g. a
+// PDF document in the `external_docs` folder of a package.
+type DigitalFile struct {
+	URI string `xml:"uri,attr" json:"uri,omitempty"`
+}
+
+// FileURIs returns the URIs of the digital files of the source.
+func (s *Source) FileURIs() []string {
+	if s == nil || s.Info == nil {
+		return nil
+	}
+	var uris []string
+	for _, f := range s.Info.DigitalFiles {
+		if f.URI != "" {
+			uris = append(uris, f.URI)
+		}
+	}
+	return uris
 }
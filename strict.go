@@ -0,0 +1,181 @@
+package ilcd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// UnknownElements returns the paths of the elements in the given data set
+// that are not captured by the data set structures of this package, e.g.
+// `/processDataSet/processInformation/geography/locationOfOperation`. The
+// paths consist of the local names of the elements and are returned in
+// document order without duplicates. Empty elements without attributes are
+// ignored as they do not contain any data. The data may be gzip compressed.
+func UnknownElements(data []byte) ([]string, error) {
+	data, err := gunzip(data)
+	if err != nil {
+		return nil, err
+	}
+	dsType, err := DataSetTypeOf(data)
+	if err != nil {
+		return nil, err
+	}
+	ds := newDataSet(dsType)
+	if err := xml.Unmarshal(data, ds); err != nil {
+		return nil, err
+	}
+	return unknownElements(data, ds)
+}
+
+// unknownElements returns the paths of the elements with data in the given
+// XML data that are lost when the given data set, which was read from these
+// data, is written back to XML. For an unknown element, only the path of the
+// element itself is returned and not the paths of its child elements.
+func unknownElements(data []byte, ds interface{}) ([]string, error) {
+	out, err := xml.Marshal(ds)
+	if err != nil {
+		return nil, err
+	}
+	known, err := elementPaths(out)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := elementPaths(data)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []string
+	for path := range paths {
+		if _, ok := known[path]; !ok {
+			candidates = append(candidates, path)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return paths[candidates[i]] < paths[candidates[j]]
+	})
+	var unknown []string
+	for _, path := range candidates {
+		if !hasParent(unknown, path) {
+			unknown = append(unknown, path)
+		}
+	}
+	return unknown, nil
+}
+
+// hasParent returns true if one of the given element paths is a parent of
+// the given path.
+func hasParent(paths []string, path string) bool {
+	for _, p := range paths {
+		if strings.HasPrefix(path, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// elementPaths returns the paths of the elements with data in the given XML
+// data, mapped to the position of their first occurrence in the document. An
+// element has data if it has attributes, non-whitespace text, or child
+// elements with data.
+func elementPaths(data []byte) (map[string]int, error) {
+	type element struct {
+		path    string
+		pos     int
+		hasData bool
+	}
+	paths := make(map[string]int)
+	var stack []element
+	pos := 0
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return paths, nil
+		}
+		if err != nil {
+			return paths, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			parent := ""
+			if len(stack) > 0 {
+				parent = stack[len(stack)-1].path
+			}
+			stack = append(stack, element{
+				path:    parent + "/" + t.Name.Local,
+				pos:     pos,
+				hasData: len(t.Attr) > 0,
+			})
+			pos++
+		case xml.CharData:
+			if len(stack) > 0 && len(bytes.TrimSpace(t)) > 0 {
+				stack[len(stack)-1].hasData = true
+			}
+		case xml.EndElement:
+			if len(stack) == 0 {
+				continue
+			}
+			e := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !e.hasData {
+				continue
+			}
+			if len(stack) > 0 {
+				stack[len(stack)-1].hasData = true
+			}
+			if first, ok := paths[e.path]; !ok || e.pos < first {
+				paths[e.path] = e.pos
+			}
+		}
+	}
+}
+
+// StrictMode enables or disables the strict mode of the reader. In strict
+// mode, reading a data set from the package fails with an error that wraps
+// ErrUnknownElements when the data set contains elements that are not
+// captured by the data set structures of this package, instead of silently
+// dropping them. This is useful to find vendor specific extensions or fields
+// that are not supported yet but makes reading slower.
+func (r *ZipReader) StrictMode(enabled bool) {
+	r.strict = enabled
+}
+
+// checkElements returns an error that wraps ErrUnknownElements and lists the
+// unknown elements if the given data contain elements that are not captured by
+// the data set that was read from them.
+func checkElements(data []byte, ds interface{}) error {
+	data, err := gunzip(data)
+	if err != nil {
+		return err
+	}
+	unknown, err := unknownElements(data, ds)
+	if err != nil {
+		return err
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w: %s", ErrUnknownElements, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// check checks the given data set that was read from the given data of the
+// package for unknown elements if strict mode is enabled.
+func (r *ZipReader) check(data []byte, ds interface{}) error {
+	if !r.strict {
+		return nil
+	}
+	return checkElements(data, ds)
+}
+
+// check checks the given data set that was read from the given data of the
+// zip file for unknown elements if strict mode is enabled.
+func (f *ZipFile) check(data []byte, ds interface{}) error {
+	if !f.strict {
+		return nil
+	}
+	return checkElements(data, ds)
+}
@@ -0,0 +1,65 @@
+package ilcd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestUnknownElements(t *testing.T) {
+	unknown, err := UnknownElements(mustRead(t, "sample_data/source.xml"))
+	if err != nil || len(unknown) != 0 {
+		t.Fatal("expected no unknown elements in the source", unknown, err)
+	}
+
+	unknown, err = UnknownElements([]byte(`<flowDataSet xmlns:x="http://example.com/x">
+		<flowInformation>
+			<dataSetInformation>
+				<UUID>fe0acd60-3ddc-11dd-aaa4-0050c2490048</UUID>
+				<x:vendorCode>42</x:vendorCode>
+				<x:empty/>
+				<x:nested><x:value>1</x:value></x:nested>
+			</dataSetInformation>
+		</flowInformation>
+	</flowDataSet>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"/flowDataSet/flowInformation/dataSetInformation/vendorCode",
+		"/flowDataSet/flowInformation/dataSetInformation/nested",
+	}
+	if len(unknown) != len(expected) {
+		t.Fatal("unexpected unknown elements", unknown)
+	}
+	for i := range expected {
+		if unknown[i] != expected[i] {
+			t.Fatal("unexpected unknown elements", unknown)
+		}
+	}
+}
+
+func TestStrictMode(t *testing.T) {
+	r := openTestZip(t)
+	uuid := "c93541fe-0b28-40b8-a890-9948e9f1d41f"
+	if _, err := r.GetProcess(uuid); err != nil {
+		t.Fatal(err)
+	}
+	r.StrictMode(true)
+	_, err := r.GetProcess(uuid)
+	if !errors.Is(err, ErrUnknownElements) || !strings.Contains(err.Error(), "/processDataSet/exchanges/exchange/") {
+		t.Fatal("expected ErrUnknownElements, got", err)
+	}
+	err = r.EachProcess(func(*Process) bool { return true })
+	if !errors.Is(err, ErrUnknownElements) {
+		t.Fatal("expected ErrUnknownElements, got", err)
+	}
+	err = r.EachSource(func(*Source) bool { return true })
+	if err != nil {
+		t.Fatal("the source should be read without errors in strict mode:", err)
+	}
+	r.StrictMode(false)
+	if _, err := r.GetProcess(uuid); err != nil {
+		t.Fatal(err)
+	}
+}
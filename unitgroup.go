@@ -1,15 +1,18 @@
 package ilcd
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"time"
+)
 
 // UnitGroup represents an ILCD unit group data set
 type UnitGroup struct {
-	XMLName     xml.Name           `xml:"unitGroupDataSet"`
-	Info        *UnitGroupInfo     `xml:"unitGroupInformation>dataSetInformation"`
-	QRef        int                `xml:"unitGroupInformation>quantitativeReference>referenceToReferenceUnit"`
-	DataEntry   *CommonDataEntry   `xml:"administrativeInformation>dataEntryBy"`
-	Publication *CommonPublication `xml:"administrativeInformation>publicationAndOwnership"`
-	Units       []Unit             `xml:"units>unit"`
+	XMLName     xml.Name           `xml:"unitGroupDataSet" json:"-"`
+	Info        *UnitGroupInfo     `xml:"unitGroupInformation>dataSetInformation" json:"info,omitempty"`
+	QRef        int                `xml:"unitGroupInformation>quantitativeReference>referenceToReferenceUnit" json:"qRef"`
+	DataEntry   *CommonDataEntry   `xml:"administrativeInformation>dataEntryBy" json:"dataEntry,omitempty"`
+	Publication *CommonPublication `xml:"administrativeInformation>publicationAndOwnership" json:"publication,omitempty"`
+	Units       []Unit             `xml:"units>unit" json:"units,omitempty"`
 }
 
 // UUID returns the UUID of the data set.
@@ -28,6 +31,40 @@ func (ug *UnitGroup) Version() string {
 	return ug.Publication.Version
 }
 
+// TimeStamp returns the time stamp of the last modification of the data set
+// as it is stored in the data entry section.
+func (ug *UnitGroup) TimeStamp() string {
+	if ug == nil {
+		return ""
+	}
+	return ug.DataEntry.timeStamp()
+}
+
+// ModifiedAt returns the parsed time stamp of the last modification of the
+// data set.
+func (ug *UnitGroup) ModifiedAt() (time.Time, error) {
+	return parseTimeStamp(ug.TimeStamp())
+}
+
+// DataFormats returns the references to the data set formats, e.g. the ILCD
+// format, that are declared in the data entry section of the data set.
+func (ug *UnitGroup) DataFormats() []Ref {
+	if ug == nil {
+		return nil
+	}
+	return ug.DataEntry.dataFormats()
+}
+
+// Extensions returns the extension elements of the unit group, e.g. vendor
+// specific data. These are the elements of the `other` block of the data set
+// information.
+func (ug *UnitGroup) Extensions() []ExtensionElement {
+	if ug == nil || ug.Info == nil {
+		return nil
+	}
+	return ug.Info.Other.Elements()
+}
+
 // ReferenceUnit returns the reference unit of an unit group.
 func (ug *UnitGroup) ReferenceUnit() *Unit {
 	if ug == nil {
@@ -43,15 +80,16 @@ func (ug *UnitGroup) ReferenceUnit() *Unit {
 
 // UnitGroupInfo <dataSetInformation>
 type UnitGroupInfo struct {
-	UUID            string           `xml:"UUID"`
-	Name            LangString       `xml:"name"`
-	Classifications []Classification `xml:"classificationInformation>classification"`
-	Comment         LangString       `xml:"generalComment"`
+	UUID            string           `xml:"UUID" json:"uuid,omitempty"`
+	Name            LangString       `xml:"name" json:"name,omitempty"`
+	Classifications []Classification `xml:"classificationInformation>classification" json:"classifications,omitempty"`
+	Comment         LangString       `xml:"generalComment" json:"comment,omitempty"`
+	Other           *Other           `xml:"other" json:"other,omitempty"`
 }
 
 // Unit contains the information of a <unit> element in an unit group data set.
 type Unit struct {
-	InternalID int     `xml:"dataSetInternalID,attr"`
-	Name       string  `xml:"name"`
-	Factor     float64 `xml:"meanValue"`
+	InternalID int     `xml:"dataSetInternalID,attr" json:"internalID"`
+	Name       string  `xml:"name" json:"name,omitempty"`
+	Factor     float64 `xml:"meanValue" json:"factor"`
 }
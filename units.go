@@ -0,0 +1,82 @@
+package ilcd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnitResolver resolves the reference units of flows in a package via their
+// reference flow properties and the reference unit groups of these flow
+// properties. The flow properties and unit groups of the package are loaded
+// when the resolver is created; flows are loaded on demand and cached. A
+// UnitResolver is not safe for concurrent use.
+type UnitResolver struct {
+	r *ZipReader
+
+	// flow UUID -> UUID of the reference flow property
+	flows map[string]string
+	// flow property UUID -> UUID of the unit group
+	props map[string]string
+	// unit group UUID -> name of the reference unit
+	units map[string]string
+}
+
+// NewUnitResolver creates a new unit resolver for the given package.
+func NewUnitResolver(r *ZipReader) (*UnitResolver, error) {
+	res := &UnitResolver{
+		r:     r,
+		flows: make(map[string]string),
+		props: make(map[string]string),
+		units: make(map[string]string),
+	}
+	err := r.EachFlowProperty(func(fp *FlowProperty) bool {
+		if fp.UnitGroup != nil {
+			res.props[strings.ToLower(fp.UUID())] = strings.ToLower(fp.UnitGroup.UUID)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = r.EachUnitGroup(func(ug *UnitGroup) bool {
+		if unit := ug.ReferenceUnit(); unit != nil {
+			res.units[strings.ToLower(ug.UUID())] = unit.Name
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UnitOf returns the name of the reference unit of the flow with the given
+// UUID. It returns an error that wraps ErrDataSetNotFound when the flow, its
+// reference flow property, or the unit group of that flow property could not
+// be found in the package.
+func (res *UnitResolver) UnitOf(flowUUID string) (string, error) {
+	key := strings.ToLower(flowUUID)
+	propID, ok := res.flows[key]
+	if !ok {
+		flow, err := res.r.GetFlow(flowUUID)
+		if err != nil {
+			return "", err
+		}
+		ref := flow.ReferenceFlowProperty()
+		if ref == nil || ref.FlowProperty == nil {
+			return "", fmt.Errorf("%w: reference flow property of flow %s",
+				ErrDataSetNotFound, flowUUID)
+		}
+		propID = strings.ToLower(ref.FlowProperty.UUID)
+		res.flows[key] = propID
+	}
+	groupID, ok := res.props[propID]
+	if !ok {
+		return "", fmt.Errorf("%w: flow property %s", ErrDataSetNotFound, propID)
+	}
+	unit, ok := res.units[groupID]
+	if !ok {
+		return "", fmt.Errorf("%w: unit group %s", ErrDataSetNotFound, groupID)
+	}
+	return unit, nil
+}
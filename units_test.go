@@ -0,0 +1,60 @@
+package ilcd
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestUnitResolver(t *testing.T) {
+	r := openTestZip(t)
+
+	// the unit group of the sample flow property is not in the test package
+	res, err := NewUnitResolver(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := res.UnitOf("fe0acd60-3ddc-11dd-aaa4-0050c2490048"); !errors.Is(err, ErrDataSetNotFound) {
+		t.Fatal("expected ErrDataSetNotFound, got", err)
+	}
+	if _, err := res.UnitOf("08a91e70-3ddc-11dd-923d-0050c2490048"); !errors.Is(err, ErrDataSetNotFound) {
+		t.Fatal("expected ErrDataSetNotFound, got", err)
+	}
+
+	// link the flow property with the sample unit group
+	path := filepath.Join(t.TempDir(), "units.zip")
+	w, err := NewZipWriter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.Transform(w, func(name string, data []byte) (string, []byte, bool, error) {
+		data = bytes.ReplaceAll(data, []byte("93a60a57-a4c8-11da-a746-0800200c9a66"),
+			[]byte("ad38d542-3fe9-439d-9b95-2f5f7752acaf"))
+		return name, data, true, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	linked, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer linked.Close()
+	res, err = NewUnitResolver(linked)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		unit, err := res.UnitOf("FE0ACD60-3DDC-11DD-AAA4-0050C2490048")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if unit != "kg" {
+			t.Fatal("expected kg, got", unit)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package ilcd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RefOf creates a reference to the given data set that is stored under the
+// given path in a package.
+func RefOf(ds DataSet, path string) Ref {
+	if ds == nil {
+		return Ref{URI: path}
+	}
+	return Ref{
+		UUID:    ds.UUID(),
+		Type:    Type(ds).String(),
+		URI:     path,
+		Version: ds.Version(),
+	}
+}
+
+// ValidateUUIDs checks the UUIDs of all data sets in the package and returns
+// references to the data sets that do not have a well-formed UUID. The URI
+// of such a reference is the path of the data set in the package.
+func (r *ZipReader) ValidateUUIDs() ([]Ref, error) {
+	var invalid []Ref
+	var gerr error
+	r.EachFile(func(f *ZipFile) bool {
+		if newDataSet(f.Type()) == nil {
+			return true
+		}
+		ds, err := f.ReadDataSet()
+		if err != nil {
+			gerr = entryErr(f.Path(), err)
+			return false
+		}
+		if !IsValidUUID(ds.UUID()) {
+			invalid = append(invalid, RefOf(ds, f.Path()))
+		}
+		return true
+	})
+	return invalid, gerr
+}
+
+// EachProcessValid iterates over each process in the package like
+// EachProcess but skips the processes that cannot be read or that do not have
+// a well-formed UUID, instead of stopping the iteration. For each skipped
+// process, the given onSkip function, which may be nil, is called with the
+// path of the process in the package and the reason why it was skipped; for
+// processes without a valid UUID, this is an error that wraps ErrInvalidUUID.
+func (r *ZipReader) EachProcessValid(fn func(*Process) bool, onSkip func(name string, err error)) {
+	skip := func(name string, err error) {
+		if onSkip != nil {
+			onSkip(name, err)
+		}
+	}
+	r.EachFile(func(f *ZipFile) bool {
+		if !IsProcessPath(f.Path()) {
+			return true
+		}
+		p, err := f.ReadProcess()
+		if err != nil {
+			skip(f.Path(), err)
+			return true
+		}
+		if !IsValidUUID(p.UUID()) {
+			skip(f.Path(), fmt.Errorf("%w: %q", ErrInvalidUUID, p.UUID()))
+			return true
+		}
+		return fn(p)
+	})
+}
+
+// ValidateVersions checks the versions of all data sets in the package and
+// returns references to the data sets that do not have a well-formed version
+// (see IsValidVersion). The URI of such a reference is the path of the data
+// set in the package.
+func (r *ZipReader) ValidateVersions() ([]Ref, error) {
+	var invalid []Ref
+	var gerr error
+	r.EachFile(func(f *ZipFile) bool {
+		if newDataSet(f.Type()) == nil {
+			return true
+		}
+		ds, err := f.ReadDataSet()
+		if err != nil {
+			gerr = entryErr(f.Path(), err)
+			return false
+		}
+		if !IsValidVersion(ds.Version()) {
+			invalid = append(invalid, RefOf(ds, f.Path()))
+		}
+		return true
+	})
+	return invalid, gerr
+}
+
+// ValidateFlows validates the flow properties of all flows in the package (see
+// Flow.ValidateFlowProperties). It returns the found problems mapped by the
+// paths of the flows in the package; flows without problems are not
+// contained in the result.
+func (r *ZipReader) ValidateFlows() (map[string][]error, error) {
+	problems := make(map[string][]error)
+	var gerr error
+	r.EachFile(func(f *ZipFile) bool {
+		if !IsFlowPath(f.Path()) {
+			return true
+		}
+		flow, err := f.ReadFlow()
+		if err != nil {
+			gerr = entryErr(f.Path(), err)
+			return false
+		}
+		if errs := flow.ValidateFlowProperties(); len(errs) > 0 {
+			problems[f.Path()] = errs
+		}
+		return true
+	})
+	return problems, gerr
+}
+
+// ValidateUnits checks that each flow property in the package refers to a unit
+// group of the package that has a reference unit. It returns an error for each
+// flow property where this is not the case; these errors wrap
+// ErrDataSetNotFound and contain the path of the flow property in the package.
+// Broken unit chains lead to missing units of flows, e.g. in inventories, so
+// it is a good idea to check them when a package is imported. The returned
+// error is non-nil when a data set could not be read.
+func (r *ZipReader) ValidateUnits() ([]error, error) {
+	// unit group UUID -> true if it has a reference unit
+	groups := make(map[string]bool)
+	err := r.EachUnitGroup(func(ug *UnitGroup) bool {
+		groups[strings.ToLower(ug.UUID())] = ug.ReferenceUnit() != nil
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []error
+	var gerr error
+	r.EachFile(func(f *ZipFile) bool {
+		if !IsFlowPropertyPath(f.Path()) {
+			return true
+		}
+		fp, err := f.ReadFlowProperty()
+		if err != nil {
+			gerr = entryErr(f.Path(), err)
+			return false
+		}
+		if fp.UnitGroup == nil || strings.TrimSpace(fp.UnitGroup.UUID) == "" {
+			problems = append(problems, entryErr(f.Path(), fmt.Errorf(
+				"%w: unit group of flow property %s", ErrDataSetNotFound, fp.UUID())))
+			return true
+		}
+		hasUnit, ok := groups[strings.ToLower(fp.UnitGroup.UUID)]
+		if !ok {
+			problems = append(problems, entryErr(f.Path(), fmt.Errorf(
+				"%w: unit group %s", ErrDataSetNotFound, fp.UnitGroup.UUID)))
+		} else if !hasUnit {
+			problems = append(problems, entryErr(f.Path(), fmt.Errorf(
+				"%w: reference unit of unit group %s", ErrDataSetNotFound,
+				fp.UnitGroup.UUID)))
+		}
+		return true
+	})
+	return problems, gerr
+}
+
+// NameMismatch describes a data set where the UUID in the name of the zip
+// entry does not match the UUID in the data set.
+type NameMismatch struct {
+	Path        string
+	NameUUID    string
+	DataSetUUID string
+}
+
+// CheckNameConsistency compares the UUID in the name of each data set entry
+// with the UUID stored in the data set and returns the entries where these
+// UUIDs do not match. Entries without a UUID in their names are ignored.
+func (r *ZipReader) CheckNameConsistency() ([]NameMismatch, error) {
+	var mismatches []NameMismatch
+	var gerr error
+	r.EachFile(func(f *ZipFile) bool {
+		if newDataSet(f.Type()) == nil {
+			return true
+		}
+		nameUUID, _ := ParseEntryName(f.Path())
+		if nameUUID == "" {
+			return true
+		}
+		ds, err := f.ReadDataSet()
+		if err != nil {
+			gerr = entryErr(f.Path(), err)
+			return false
+		}
+		if !strings.EqualFold(nameUUID, ds.UUID()) {
+			mismatches = append(mismatches, NameMismatch{
+				Path:        f.Path(),
+				NameUUID:    nameUUID,
+				DataSetUUID: ds.UUID(),
+			})
+		}
+		return true
+	})
+	return mismatches, gerr
+}
@@ -0,0 +1,214 @@
+package ilcd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateUUIDs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "uuids.zip")
+	w, _ := NewZipWriter(path)
+	w.Write("ILCD/flows/ok.xml", []byte(`<flowDataSet><flowInformation><dataSetInformation>
+		<UUID>fe0acd60-3ddc-11dd-aaa4-0050c2490048</UUID></dataSetInformation></flowInformation></flowDataSet>`))
+	w.Write("ILCD/flows/bad.xml", []byte(`<flowDataSet><flowInformation><dataSetInformation>
+		<UUID>not-a-uuid</UUID></dataSetInformation></flowInformation></flowDataSet>`))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	invalid, err := r.ValidateUUIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(invalid) != 1 || invalid[0].UUID != "not-a-uuid" || invalid[0].URI != "ILCD/flows/bad.xml" {
+		t.Fatal("expected exactly one invalid UUID, got", invalid)
+	}
+	if invalid[0].DataSetType() != FlowDataSet {
+		t.Fatal("wrong data set type of invalid data set")
+	}
+}
+
+func TestCheckNameConsistency(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "names.zip")
+	w, _ := NewZipWriter(path)
+	flow := `<flowDataSet><flowInformation><dataSetInformation>
+		<UUID>fe0acd60-3ddc-11dd-aaa4-0050c2490048</UUID></dataSetInformation></flowInformation></flowDataSet>`
+	w.Write("ILCD/flows/fe0acd60-3ddc-11dd-aaa4-0050c2490048.xml", []byte(flow))
+	w.Write("ILCD/flows/08a91e70-3ddc-11dd-91d5-0050c2490048.xml", []byte(flow))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	mismatches, err := r.CheckNameConsistency()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mismatches) != 1 || mismatches[0].NameUUID != "08a91e70-3ddc-11dd-91d5-0050c2490048" {
+		t.Fatal("expected exactly one mismatch, got", mismatches)
+	}
+}
+
+func TestValidateFlows(t *testing.T) {
+	r := openTestZip(t)
+	problems, err := r.ValidateFlows()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 0 {
+		t.Fatal("unexpected problems", problems)
+	}
+}
+
+func TestValidateUnits(t *testing.T) {
+	r := openTestZip(t)
+	problems, err := r.ValidateUnits()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the flow property of the sample package refers to a missing unit group
+	if len(problems) != 1 || !errors.Is(problems[0], ErrDataSetNotFound) {
+		t.Fatal("expected a missing unit group, got", problems)
+	}
+
+	fp, err := ioutil.ReadFile("sample_data/flowprop.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ug, err := ioutil.ReadFile("sample_data/unitgroup.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fp = bytes.ReplaceAll(fp, []byte("93a60a57-a4c8-11da-a746-0800200c9a66"),
+		[]byte("ad38d542-3fe9-439d-9b95-2f5f7752acaf"))
+	path := filepath.Join(t.TempDir(), "units.zip")
+	err = WriteZip(path, map[string][]byte{
+		"ILCD/flowproperties/93a60a56-a3c8-11da-a746-0800200b9a66.xml": fp,
+		"ILCD/unitgroups/ad38d542-3fe9-439d-9b95-2f5f7752acaf.xml":     ug,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err = NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if problems, err = r.ValidateUnits(); err != nil || len(problems) != 0 {
+		t.Fatal("unexpected problems", problems, err)
+	}
+
+	ug = bytes.Replace(ug,
+		[]byte("<referenceToReferenceUnit>0</referenceToReferenceUnit>"),
+		[]byte("<referenceToReferenceUnit>42</referenceToReferenceUnit>"), 1)
+	path = filepath.Join(t.TempDir(), "no_ref_unit.zip")
+	err = WriteZip(path, map[string][]byte{
+		"ILCD/flowproperties/93a60a56-a3c8-11da-a746-0800200b9a66.xml": fp,
+		"ILCD/unitgroups/ad38d542-3fe9-439d-9b95-2f5f7752acaf.xml":     ug,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err = NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	problems, err = r.ValidateUnits()
+	if err != nil || len(problems) != 1 || !errors.Is(problems[0], ErrDataSetNotFound) {
+		t.Fatal("expected a missing reference unit, got", problems, err)
+	}
+}
+
+func TestValidateVersions(t *testing.T) {
+	r := openTestZip(t)
+	invalid, err := r.ValidateVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(invalid) != 0 {
+		t.Fatal("unexpected invalid versions", invalid)
+	}
+
+	data, err := xml.Marshal(NewFlow("08a91e70-3ddc-11dd-923d-0050c2490048").
+		SetVersion("1.0").Build())
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "versions.zip")
+	err = WriteZip(path, map[string][]byte{
+		"ILCD/flows/08a91e70-3ddc-11dd-923d-0050c2490048.xml": data,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err = NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	invalid, err = r.ValidateVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(invalid) != 1 || invalid[0].Version != "1.0" {
+		t.Fatal("expected one invalid version, got", invalid)
+	}
+}
+
+func TestEachProcessValid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "processes.zip")
+	err := WriteZip(path, map[string][]byte{
+		"ILCD/processes/a_ok.xml": []byte(`<processDataSet><processInformation><dataSetInformation>
+			<UUID>c93541fe-0b28-40b8-a890-9948e9f1d41f</UUID>
+		</dataSetInformation></processInformation></processDataSet>`),
+		"ILCD/processes/b_no_uuid.xml": []byte(`<processDataSet/>`),
+		"ILCD/processes/c_broken.xml":  []byte(`<processDataSet>`),
+		"ILCD/flows/d_flow.xml":        []byte(`<flowDataSet/>`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	var processes []string
+	skipped := make(map[string]error)
+	r.EachProcessValid(func(p *Process) bool {
+		processes = append(processes, p.UUID())
+		return true
+	}, func(name string, err error) {
+		skipped[name] = err
+	})
+	if len(processes) != 1 || processes[0] != "c93541fe-0b28-40b8-a890-9948e9f1d41f" {
+		t.Fatal("unexpected valid processes", processes)
+	}
+	if len(skipped) != 2 || !errors.Is(skipped["ILCD/processes/b_no_uuid.xml"], ErrInvalidUUID) ||
+		skipped["ILCD/processes/c_broken.xml"] == nil {
+		t.Fatal("unexpected skipped processes", skipped)
+	}
+
+	// a nil onSkip function should be allowed
+	count := 0
+	r.EachProcessValid(func(*Process) bool {
+		count++
+		return true
+	}, nil)
+	if count != 1 {
+		t.Fatal("expected one valid process")
+	}
+}
@@ -0,0 +1,71 @@
+package ilcd
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var validVersionRegex = regexp.MustCompile(`^\d{2}\.\d{2}\.\d{3}$`)
+
+// IsValidVersion returns true if the given string is a well-formed data set
+// version of the format `NN.NN.NNN`, e.g. `03.00.000`.
+func IsValidVersion(s string) bool {
+	return validVersionRegex.MatchString(s)
+}
+
+// CompareVersions compares the given data set versions, e.g. `03.00.000`, and
+// returns -1 if a is lower than b, 1 if a is greater than b, and 0 if both
+// versions are equal. The version parts are compared numerically so that
+// `1.0` and `01.00.000` are equal. Parts that are not numbers are compared as
+// strings and lower than numbers.
+func CompareVersions(a, b string) int {
+	as := versionParts(a)
+	bs := versionParts(b)
+	n := len(as)
+	if len(bs) > n {
+		n = len(bs)
+	}
+	for i := 0; i < n; i++ {
+		ap, bp := "0", "0"
+		if i < len(as) {
+			ap = as[i]
+		}
+		if i < len(bs) {
+			bp = bs[i]
+		}
+		if c := compareVersionPart(ap, bp); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func versionParts(version string) []string {
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return nil
+	}
+	return strings.Split(version, ".")
+}
+
+func compareVersionPart(a, b string) int {
+	ai, aerr := strconv.ParseUint(a, 10, 64)
+	bi, berr := strconv.ParseUint(b, 10, 64)
+	switch {
+	case aerr == nil && berr == nil:
+		if ai < bi {
+			return -1
+		}
+		if ai > bi {
+			return 1
+		}
+		return 0
+	case aerr == nil:
+		return 1
+	case berr == nil:
+		return -1
+	default:
+		return strings.Compare(a, b)
+	}
+}
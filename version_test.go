@@ -0,0 +1,40 @@
+package ilcd
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"03.00.000", "03.00.000", 0},
+		{"1.0", "01.00.000", 0},
+		{"01.00.001", "01.00.000", 1},
+		{"01.09.000", "01.10.000", -1},
+		{"02.00.000", "01.99.999", 1},
+		{"", "00.00.000", 0},
+		{"", "00.00.001", -1},
+		{"01.00.x", "01.00.000", -1},
+	}
+	for _, test := range tests {
+		if c := CompareVersions(test.a, test.b); c != test.expected {
+			t.Fatal("CompareVersions", test.a, test.b, "=", c, "expected", test.expected)
+		}
+		if c := CompareVersions(test.b, test.a); c != -test.expected {
+			t.Fatal("CompareVersions", test.b, test.a, "=", c, "expected", -test.expected)
+		}
+	}
+}
+
+func TestIsValidVersion(t *testing.T) {
+	for _, v := range []string{"00.00.000", "03.00.000", "99.99.999"} {
+		if !IsValidVersion(v) {
+			t.Fatal("version should be valid:", v)
+		}
+	}
+	for _, v := range []string{"", "1.0", "01.00", "01.00.0000", "01.00.00a", " 01.00.000"} {
+		if IsValidVersion(v) {
+			t.Fatal("version should be invalid:", v)
+		}
+	}
+}
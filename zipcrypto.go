@@ -0,0 +1,129 @@
+package ilcd
+
+import (
+	"archive/zip"
+	"compress/flate"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+)
+
+const (
+	flagEncrypted      = 0x1
+	flagDataDescriptor = 0x8
+
+	// the method identifier of WinZip AES encrypted entries
+	methodAES = 99
+)
+
+var crcTable = crc32.MakeTable(crc32.IEEE)
+
+// zipCrypto implements the traditional PKWARE encryption of zip entries, see
+// section 6.1 of the PKWARE APPNOTE.TXT.
+type zipCrypto struct {
+	keys [3]uint32
+}
+
+func newZipCrypto(password string) *zipCrypto {
+	z := &zipCrypto{keys: [3]uint32{0x12345678, 0x23456789, 0x34567890}}
+	for i := 0; i < len(password); i++ {
+		z.update(password[i])
+	}
+	return z
+}
+
+func (z *zipCrypto) update(b byte) {
+	z.keys[0] = crcTable[(z.keys[0]^uint32(b))&0xff] ^ (z.keys[0] >> 8)
+	z.keys[1] = (z.keys[1]+(z.keys[0]&0xff))*134775813 + 1
+	z.keys[2] = crcTable[(z.keys[2]^(z.keys[1]>>24))&0xff] ^ (z.keys[2] >> 8)
+}
+
+func (z *zipCrypto) streamByte() byte {
+	temp := (z.keys[2] | 2) & 0xffff
+	return byte((temp * (temp ^ 1)) >> 8)
+}
+
+func (z *zipCrypto) decrypt(data []byte) {
+	for i, c := range data {
+		p := c ^ z.streamByte()
+		z.update(p)
+		data[i] = p
+	}
+}
+
+// zipCryptoReader decrypts the data of an underlying reader.
+type zipCryptoReader struct {
+	r io.Reader
+	z *zipCrypto
+}
+
+func (r *zipCryptoReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.z.decrypt(p[:n])
+	return n, err
+}
+
+// checksumReader verifies the CRC32 checksum of the data of an underlying
+// reader when the end of the data is reached.
+type checksumReader struct {
+	r    io.Reader
+	hash hash.Hash32
+	crc  uint32
+}
+
+func (r *checksumReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.hash.Write(p[:n])
+	if err == io.EOF && r.hash.Sum32() != r.crc {
+		return n, zip.ErrChecksum
+	}
+	return n, err
+}
+
+// isEncrypted returns true if the given zip entry is encrypted.
+func isEncrypted(f *zip.File) bool {
+	return f.Flags&flagEncrypted != 0
+}
+
+// openEncrypted opens a reader on the decrypted and decompressed data of the
+// given encrypted zip entry.
+func openEncrypted(f *zip.File, password string) (io.ReadCloser, error) {
+	if password == "" {
+		return nil, fmt.Errorf("%w: %s", ErrEncrypted, f.Name)
+	}
+	if f.Method == methodAES {
+		return nil, fmt.Errorf("%w: AES encryption is not supported: %s",
+			ErrEncrypted, f.Name)
+	}
+	raw, err := f.OpenRaw()
+	if err != nil {
+		return nil, err
+	}
+	z := newZipCrypto(password)
+	header := make([]byte, 12)
+	if _, err := io.ReadFull(raw, header); err != nil {
+		return nil, err
+	}
+	z.decrypt(header)
+	check := byte(f.CRC32 >> 24)
+	if f.Flags&flagDataDescriptor != 0 {
+		check = byte(f.ModifiedTime >> 8)
+	}
+	if header[11] != check {
+		return nil, fmt.Errorf("%w: %s", ErrPassword, f.Name)
+	}
+	var data io.Reader = &zipCryptoReader{r: raw, z: z}
+	switch f.Method {
+	case zip.Store:
+	case zip.Deflate:
+		data = flate.NewReader(data)
+	default:
+		return nil, zip.ErrAlgorithm
+	}
+	return ioutil.NopCloser(&checksumReader{
+		r:    data,
+		hash: crc32.NewIEEE(),
+		crc:  f.CRC32}), nil
+}
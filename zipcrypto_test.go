@@ -0,0 +1,125 @@
+package ilcd
+
+import (
+	"archive/zip"
+	"errors"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func (z *zipCrypto) encrypt(data []byte) {
+	for i, p := range data {
+		c := p ^ z.streamByte()
+		z.update(p)
+		data[i] = c
+	}
+}
+
+// createEncryptedZip creates a package with a single stored entry that is
+// encrypted with the given password.
+func createEncryptedZip(t *testing.T, name string, content []byte, password string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "encrypted.zip")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	w := zip.NewWriter(file)
+	crc := crc32.ChecksumIEEE(content)
+	data := make([]byte, 12, 12+len(content))
+	data[11] = byte(crc >> 24)
+	data = append(data, content...)
+	newZipCrypto(password).encrypt(data)
+	raw, err := w.CreateRaw(&zip.FileHeader{
+		Name:               name,
+		Method:             zip.Store,
+		Flags:              flagEncrypted,
+		CRC32:              crc,
+		CompressedSize64:   uint64(len(data)),
+		UncompressedSize64: uint64(len(content)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := raw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestEncryptedZip(t *testing.T) {
+	content := []byte("<contactDataSet/>")
+	path := createEncryptedZip(t, "ILCD/contacts/a.xml", content, "secret")
+
+	r, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	r.EachFile(func(f *ZipFile) bool {
+		if !f.IsEncrypted() {
+			t.Fatal("entry should be encrypted")
+		}
+		if _, err := f.Read(); !errors.Is(err, ErrEncrypted) {
+			t.Fatal("expected ErrEncrypted, got", err)
+		}
+		return true
+	})
+
+	wrong, err := NewEncryptedZipReader(path, "wrong")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wrong.Close()
+	wrong.EachFile(func(f *ZipFile) bool {
+		if _, err := f.Read(); !errors.Is(err, ErrPassword) {
+			t.Fatal("expected ErrPassword, got", err)
+		}
+		return true
+	})
+
+	er, err := NewEncryptedZipReader(path, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer er.Close()
+	er.EachFile(func(f *ZipFile) bool {
+		data, err := f.Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != string(content) {
+			t.Fatal("failed to decrypt entry")
+		}
+		return true
+	})
+}
+
+func TestCheckIntegrityEncrypted(t *testing.T) {
+	path := createEncryptedZip(t, "ILCD/contacts/a.xml", []byte("<contactDataSet/>"), "secret")
+	r, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	broken, encrypted := r.CheckIntegrity()
+	if len(broken) != 0 || len(encrypted) != 1 || encrypted[0] != "ILCD/contacts/a.xml" {
+		t.Fatal("expected one encrypted and no broken entry, got", broken, encrypted)
+	}
+
+	withPassword, err := NewEncryptedZipReader(path, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer withPassword.Close()
+	broken, encrypted = withPassword.CheckIntegrity()
+	if len(broken) != 0 || len(encrypted) != 0 {
+		t.Fatal("the entry should be checked with the password, got", broken, encrypted)
+	}
+}
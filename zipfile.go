@@ -2,18 +2,24 @@ package ilcd
 
 import (
 	"archive/zip"
+	"bytes"
+	"io"
 	"io/ioutil"
+	"time"
 )
 
 // ZipFile embedds the type `File` from the `archive/zip` package and provides
 // additional ILCD specific methods.
 type ZipFile struct {
-	f *zip.File
+	f        *zip.File
+	password string
+	pool     *stringPool
+	strict   bool
 }
 
 // newZipFile initializes a new ZipFile from the given archive file.
 func newZipFile(f *zip.File) *ZipFile {
-	return &ZipFile{f}
+	return &ZipFile{f: f}
 }
 
 // Path returns the path of the zip file within the zip package.
@@ -21,9 +27,68 @@ func (f *ZipFile) Path() string {
 	return f.f.Name
 }
 
-// Reads the decompressed data from the zip file.
+// EntryInfo contains the meta data of a zip entry as they are stored in the
+// central directory of the package.
+type EntryInfo struct {
+	Name             string
+	CRC32            uint32
+	CompressedSize   uint64
+	UncompressedSize uint64
+	Modified         time.Time
+	Method           uint16
+}
+
+// Info returns the meta data of the zip file. This does not decompress the
+// file.
+func (f *ZipFile) Info() *EntryInfo {
+	return &EntryInfo{
+		Name:             f.f.Name,
+		CRC32:            f.f.CRC32,
+		CompressedSize:   f.f.CompressedSize64,
+		UncompressedSize: f.f.UncompressedSize64,
+		Modified:         f.f.Modified,
+		Method:           f.f.Method,
+	}
+}
+
+// Method returns the compression method of the zip file, e.g. `zip.Store` or
+// `zip.Deflate`.
+func (f *ZipFile) Method() uint16 {
+	return f.f.Method
+}
+
+// IsCompressed returns true if the zip file is not stored uncompressed.
+func (f *ZipFile) IsCompressed() bool {
+	return f.f.Method != zip.Store
+}
+
+// IsEncrypted returns true if the zip file is encrypted.
+func (f *ZipFile) IsEncrypted() bool {
+	return isEncrypted(f.f)
+}
+
+// open returns a reader on the decompressed (and decrypted) data of the zip
+// file.
+func (f *ZipFile) open() (io.ReadCloser, error) {
+	if isEncrypted(f.f) {
+		return openEncrypted(f.f, f.password)
+	}
+	return f.f.Open()
+}
+
+// Open returns a reader on the decompressed data of the zip file, so that the
+// entry can be processed as a stream without reading it into memory first.
+// The reader must be closed by the caller. For encrypted files, the same rules
+// as for Read apply.
+func (f *ZipFile) Open() (io.ReadCloser, error) {
+	return f.open()
+}
+
+// Reads the decompressed data from the zip file. For encrypted files, an error
+// that wraps ErrEncrypted is returned when the package was not opened with a
+// password.
 func (f *ZipFile) Read() ([]byte, error) {
-	reader, err := f.f.Open()
+	reader, err := f.open()
 	if err != nil {
 		return nil, err
 	}
@@ -37,13 +102,32 @@ func (f *ZipFile) Read() ([]byte, error) {
 	return data, nil
 }
 
+// readInto reads the decompressed data from the zip file into the given
+// buffer.
+func (f *ZipFile) readInto(buf *bytes.Buffer) error {
+	reader, err := f.open()
+	if err != nil {
+		return err
+	}
+	if _, err := buf.ReadFrom(reader); err != nil {
+		reader.Close()
+		return err
+	}
+	return reader.Close()
+}
+
 // ReadModel reads a life cycle model data set from the zip file.
 func (f *ZipFile) ReadModel() (*Model, error) {
 	data, err := f.Read()
 	if err != nil {
 		return nil, err
 	}
-	return ReadModel(data)
+	ds, err := ReadModel(data)
+	if err == nil {
+		err = f.check(data, ds)
+	}
+	f.pool.intern(ds)
+	return ds, err
 }
 
 // ReadMethod reads a Method data set from the zip file.
@@ -52,7 +136,12 @@ func (f *ZipFile) ReadMethod() (*Method, error) {
 	if err != nil {
 		return nil, err
 	}
-	return ReadMethod(data)
+	ds, err := ReadMethod(data)
+	if err == nil {
+		err = f.check(data, ds)
+	}
+	f.pool.intern(ds)
+	return ds, err
 }
 
 // ReadProcess reads a Process data set from the zip file.
@@ -61,7 +150,12 @@ func (f *ZipFile) ReadProcess() (*Process, error) {
 	if err != nil {
 		return nil, err
 	}
-	return ReadProcess(data)
+	ds, err := ReadProcess(data)
+	if err == nil {
+		err = f.check(data, ds)
+	}
+	f.pool.intern(ds)
+	return ds, err
 }
 
 // ReadFlow reads a Flow data set from the zip file.
@@ -70,7 +164,12 @@ func (f *ZipFile) ReadFlow() (*Flow, error) {
 	if err != nil {
 		return nil, err
 	}
-	return ReadFlow(data)
+	ds, err := ReadFlow(data)
+	if err == nil {
+		err = f.check(data, ds)
+	}
+	f.pool.intern(ds)
+	return ds, err
 }
 
 // ReadFlowProperty reads a FlowProperty data set from the zip file.
@@ -79,7 +178,12 @@ func (f *ZipFile) ReadFlowProperty() (*FlowProperty, error) {
 	if err != nil {
 		return nil, err
 	}
-	return ReadFlowProperty(data)
+	ds, err := ReadFlowProperty(data)
+	if err == nil {
+		err = f.check(data, ds)
+	}
+	f.pool.intern(ds)
+	return ds, err
 }
 
 // ReadUnitGroup reads a UnitGroup data set from the zip file.
@@ -88,7 +192,12 @@ func (f *ZipFile) ReadUnitGroup() (*UnitGroup, error) {
 	if err != nil {
 		return nil, err
 	}
-	return ReadUnitGroup(data)
+	ds, err := ReadUnitGroup(data)
+	if err == nil {
+		err = f.check(data, ds)
+	}
+	f.pool.intern(ds)
+	return ds, err
 }
 
 // ReadSource reads a Source data set from the zip file.
@@ -97,7 +206,12 @@ func (f *ZipFile) ReadSource() (*Source, error) {
 	if err != nil {
 		return nil, err
 	}
-	return ReadSource(data)
+	ds, err := ReadSource(data)
+	if err == nil {
+		err = f.check(data, ds)
+	}
+	f.pool.intern(ds)
+	return ds, err
 }
 
 // ReadContact reads a Contact data set from the zip file.
@@ -106,7 +220,34 @@ func (f *ZipFile) ReadContact() (*Contact, error) {
 	if err != nil {
 		return nil, err
 	}
-	return ReadContact(data)
+	ds, err := ReadContact(data)
+	if err == nil {
+		err = f.check(data, ds)
+	}
+	f.pool.intern(ds)
+	return ds, err
+}
+
+// ReadDataSet reads the data set from the zip file. The type of the data set
+// is inferred from the path of the zip file. It returns ErrUnsupportedType if
+// the zip file does not contain a data set.
+func (f *ZipFile) ReadDataSet() (DataSet, error) {
+	ds := newDataSet(f.Type())
+	if ds == nil {
+		return nil, ErrUnsupportedType
+	}
+	data, err := f.Read()
+	if err != nil {
+		return nil, err
+	}
+	if err := unmarshalXML(data, ds); err != nil {
+		return nil, err
+	}
+	if err := f.check(data, ds); err != nil {
+		return nil, err
+	}
+	f.pool.intern(ds)
+	return ds, nil
 }
 
 /*
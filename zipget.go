@@ -0,0 +1,411 @@
+package ilcd
+
+import (
+	"fmt"
+	"sort"
+)
+
+// getData returns the raw data of the data set with the given type and UUID.
+func (r *ZipReader) getData(dsType DataSetType, uuid string) ([]byte, error) {
+	return r.readData(r.FindDataSet(dsType, uuid))
+}
+
+// getDataVersion returns the raw data of the data set with the given type,
+// UUID, and version.
+func (r *ZipReader) getDataVersion(dsType DataSetType, uuid, version string) ([]byte, error) {
+	return r.readData(r.FindDataSetVersion(dsType, uuid, version))
+}
+
+// readData reads the data of the given zip file of a data set. Gzip compressed
+// data are decompressed.
+func (r *ZipReader) readData(f *ZipFile) ([]byte, error) {
+	if f == nil {
+		return nil, ErrDataSetNotFound
+	}
+	data, err := f.Read()
+	if err == nil {
+		data, err = gunzip(data)
+	}
+	if err != nil {
+		return nil, entryErr(f.Path(), err)
+	}
+	return data, nil
+}
+
+// GetModelData returns the raw data of the life cycle model data set with the
+// given UUID. It returns ErrDataSetNotFound if there is no such data set in the
+// package.
+func (r *ZipReader) GetModelData(uuid string) ([]byte, error) {
+	return r.getData(ModelDataSet, uuid)
+}
+
+// GetModelDataVersion returns the raw data of the life cycle model data set
+// with the given UUID and version. It returns ErrDataSetNotFound if there is no
+// such data set in the package.
+func (r *ZipReader) GetModelDataVersion(uuid, version string) ([]byte, error) {
+	return r.getDataVersion(ModelDataSet, uuid, version)
+}
+
+// GetModel returns the life cycle model data set with the given UUID. It
+// returns ErrDataSetNotFound if there is no such data set in the package.
+func (r *ZipReader) GetModel(uuid string) (*Model, error) {
+	data, err := r.GetModelData(uuid)
+	if err != nil {
+		return nil, err
+	}
+	ds, err := ReadModel(data)
+	if err == nil {
+		err = r.check(data, ds)
+	}
+	r.pool.intern(ds)
+	return ds, err
+}
+
+// GetMethodData returns the raw data of the LCIA method data set with the given
+// UUID. It returns ErrDataSetNotFound if there is no such data set in the
+// package.
+func (r *ZipReader) GetMethodData(uuid string) ([]byte, error) {
+	return r.getData(MethodDataSet, uuid)
+}
+
+// GetMethodDataVersion returns the raw data of the LCIA method data set with
+// the given UUID and version. It returns ErrDataSetNotFound if there is no such
+// data set in the package.
+func (r *ZipReader) GetMethodDataVersion(uuid, version string) ([]byte, error) {
+	return r.getDataVersion(MethodDataSet, uuid, version)
+}
+
+// GetMethod returns the LCIA method data set with the given UUID. It returns
+// ErrDataSetNotFound if there is no such data set in the package.
+func (r *ZipReader) GetMethod(uuid string) (*Method, error) {
+	data, err := r.GetMethodData(uuid)
+	if err != nil {
+		return nil, err
+	}
+	ds, err := ReadMethod(data)
+	if err == nil {
+		err = r.check(data, ds)
+	}
+	r.pool.intern(ds)
+	return ds, err
+}
+
+// GetProcessData returns the raw data of the process data set with the given
+// UUID. It returns ErrDataSetNotFound if there is no such data set in the
+// package.
+func (r *ZipReader) GetProcessData(uuid string) ([]byte, error) {
+	return r.getData(ProcessDataSet, uuid)
+}
+
+// GetProcessDataVersion returns the raw data of the process data set with the
+// given UUID and version. It returns ErrDataSetNotFound if there is no such
+// data set in the package.
+func (r *ZipReader) GetProcessDataVersion(uuid, version string) ([]byte, error) {
+	return r.getDataVersion(ProcessDataSet, uuid, version)
+}
+
+// GetProcess returns the process data set with the given UUID. It returns
+// ErrDataSetNotFound if there is no such data set in the package.
+func (r *ZipReader) GetProcess(uuid string) (*Process, error) {
+	data, err := r.GetProcessData(uuid)
+	if err != nil {
+		return nil, err
+	}
+	ds, err := ReadProcess(data)
+	if err == nil {
+		err = r.check(data, ds)
+	}
+	r.pool.intern(ds)
+	return ds, err
+}
+
+// GetFlowData returns the raw data of the flow data set with the given UUID. It
+// returns ErrDataSetNotFound if there is no such data set in the package.
+func (r *ZipReader) GetFlowData(uuid string) ([]byte, error) {
+	return r.getData(FlowDataSet, uuid)
+}
+
+// GetFlowDataVersion returns the raw data of the flow data set with the given
+// UUID and version. It returns ErrDataSetNotFound if there is no such data set
+// in the package.
+func (r *ZipReader) GetFlowDataVersion(uuid, version string) ([]byte, error) {
+	return r.getDataVersion(FlowDataSet, uuid, version)
+}
+
+// GetFlow returns the flow data set with the given UUID. It returns
+// ErrDataSetNotFound if there is no such data set in the package.
+func (r *ZipReader) GetFlow(uuid string) (*Flow, error) {
+	data, err := r.GetFlowData(uuid)
+	if err != nil {
+		return nil, err
+	}
+	ds, err := ReadFlow(data)
+	if err == nil {
+		err = r.check(data, ds)
+	}
+	r.pool.intern(ds)
+	return ds, err
+}
+
+// GetFlowPropertyData returns the raw data of the flow property data set with
+// the given UUID. It returns ErrDataSetNotFound if there is no such data set in
+// the package.
+func (r *ZipReader) GetFlowPropertyData(uuid string) ([]byte, error) {
+	return r.getData(FlowPropertyDataSet, uuid)
+}
+
+// GetFlowPropertyDataVersion returns the raw data of the flow property data set
+// with the given UUID and version. It returns ErrDataSetNotFound if there is no
+// such data set in the package.
+func (r *ZipReader) GetFlowPropertyDataVersion(uuid, version string) ([]byte, error) {
+	return r.getDataVersion(FlowPropertyDataSet, uuid, version)
+}
+
+// GetFlowProperty returns the flow property data set with the given UUID. It
+// returns ErrDataSetNotFound if there is no such data set in the package.
+func (r *ZipReader) GetFlowProperty(uuid string) (*FlowProperty, error) {
+	data, err := r.GetFlowPropertyData(uuid)
+	if err != nil {
+		return nil, err
+	}
+	ds, err := ReadFlowProperty(data)
+	if err == nil {
+		err = r.check(data, ds)
+	}
+	r.pool.intern(ds)
+	return ds, err
+}
+
+// GetUnitGroupData returns the raw data of the unit group data set with the
+// given UUID. It returns ErrDataSetNotFound if there is no such data set in the
+// package.
+func (r *ZipReader) GetUnitGroupData(uuid string) ([]byte, error) {
+	return r.getData(UnitGroupDataSet, uuid)
+}
+
+// GetUnitGroupDataVersion returns the raw data of the unit group data set with
+// the given UUID and version. It returns ErrDataSetNotFound if there is no such
+// data set in the package.
+func (r *ZipReader) GetUnitGroupDataVersion(uuid, version string) ([]byte, error) {
+	return r.getDataVersion(UnitGroupDataSet, uuid, version)
+}
+
+// GetUnitGroup returns the unit group data set with the given UUID. It returns
+// ErrDataSetNotFound if there is no such data set in the package.
+func (r *ZipReader) GetUnitGroup(uuid string) (*UnitGroup, error) {
+	data, err := r.GetUnitGroupData(uuid)
+	if err != nil {
+		return nil, err
+	}
+	ds, err := ReadUnitGroup(data)
+	if err == nil {
+		err = r.check(data, ds)
+	}
+	r.pool.intern(ds)
+	return ds, err
+}
+
+// GetSourceData returns the raw data of the source data set with the given
+// UUID. It returns ErrDataSetNotFound if there is no such data set in the
+// package.
+func (r *ZipReader) GetSourceData(uuid string) ([]byte, error) {
+	return r.getData(SourceDataSet, uuid)
+}
+
+// GetSourceDataVersion returns the raw data of the source data set with the
+// given UUID and version. It returns ErrDataSetNotFound if there is no such
+// data set in the package.
+func (r *ZipReader) GetSourceDataVersion(uuid, version string) ([]byte, error) {
+	return r.getDataVersion(SourceDataSet, uuid, version)
+}
+
+// GetSource returns the source data set with the given UUID. It returns
+// ErrDataSetNotFound if there is no such data set in the package.
+func (r *ZipReader) GetSource(uuid string) (*Source, error) {
+	data, err := r.GetSourceData(uuid)
+	if err != nil {
+		return nil, err
+	}
+	ds, err := ReadSource(data)
+	if err == nil {
+		err = r.check(data, ds)
+	}
+	r.pool.intern(ds)
+	return ds, err
+}
+
+// GetContactData returns the raw data of the contact data set with the given
+// UUID. It returns ErrDataSetNotFound if there is no such data set in the
+// package.
+func (r *ZipReader) GetContactData(uuid string) ([]byte, error) {
+	return r.getData(ContactDataSet, uuid)
+}
+
+// GetContactDataVersion returns the raw data of the contact data set with the
+// given UUID and version. It returns ErrDataSetNotFound if there is no such
+// data set in the package.
+func (r *ZipReader) GetContactDataVersion(uuid, version string) ([]byte, error) {
+	return r.getDataVersion(ContactDataSet, uuid, version)
+}
+
+// GetContact returns the contact data set with the given UUID. It returns
+// ErrDataSetNotFound if there is no such data set in the package.
+func (r *ZipReader) GetContact(uuid string) (*Contact, error) {
+	data, err := r.GetContactData(uuid)
+	if err != nil {
+		return nil, err
+	}
+	ds, err := ReadContact(data)
+	if err == nil {
+		err = r.check(data, ds)
+	}
+	r.pool.intern(ds)
+	return ds, err
+}
+
+// GetExternalDoc returns the content of the external document with the given
+// name (see FindExternalDoc). It returns ErrEntryNotFound if there is no such
+// document in the package. For large documents, GetExternalDocFile should be
+// used instead as this function loads the complete document into memory.
+func (r *ZipReader) GetExternalDoc(name string) ([]byte, error) {
+	f := r.FindExternalDoc(name)
+	if f == nil {
+		return nil, ErrEntryNotFound
+	}
+	data, err := f.Read()
+	if err != nil {
+		return nil, entryErr(f.Path(), err)
+	}
+	return data, nil
+}
+
+// GetExternalDocFile streams the content of the external document with the
+// given name (see FindExternalDoc) into the file with the given path. The
+// document is not loaded into memory. It returns ErrEntryNotFound if there is
+// no such document in the package.
+func (r *ZipReader) GetExternalDocFile(name, destPath string) error {
+	f := r.FindExternalDoc(name)
+	if f == nil {
+		return ErrEntryNotFound
+	}
+	return entryErr(f.Path(), extractFile(f, destPath))
+}
+
+// ResolveReferenceFlowProperty returns the reference flow property of the flow
+// with the given UUID. It returns an error that wraps ErrDataSetNotFound if
+// the flow, its reference to the flow property, or the flow property data set
+// is missing.
+func (r *ZipReader) ResolveReferenceFlowProperty(flowUUID string) (*FlowProperty, error) {
+	flow, err := r.GetFlow(flowUUID)
+	if err != nil {
+		return nil, err
+	}
+	ref := flow.ReferenceFlowProperty()
+	if ref == nil || ref.FlowProperty == nil {
+		return nil, fmt.Errorf("%w: reference flow property of flow %s",
+			ErrDataSetNotFound, flowUUID)
+	}
+	return r.GetFlowProperty(ref.FlowProperty.UUID)
+}
+
+// has returns true if the package contains a data set with the given type and
+// UUID. Only the index of the package is checked; no data set is parsed.
+func (r *ZipReader) has(dsType DataSetType, uuid string) bool {
+	return r.index().find(dsType, uuid) != nil
+}
+
+// HasModel returns true if the package contains a life cycle model data set
+// with the given UUID.
+func (r *ZipReader) HasModel(uuid string) bool {
+	return r.has(ModelDataSet, uuid)
+}
+
+// HasMethod returns true if the package contains an LCIA method data set with
+// the given UUID.
+func (r *ZipReader) HasMethod(uuid string) bool {
+	return r.has(MethodDataSet, uuid)
+}
+
+// HasProcess returns true if the package contains a process data set with the
+// given UUID.
+func (r *ZipReader) HasProcess(uuid string) bool {
+	return r.has(ProcessDataSet, uuid)
+}
+
+// HasFlow returns true if the package contains a flow data set with the given
+// UUID.
+func (r *ZipReader) HasFlow(uuid string) bool {
+	return r.has(FlowDataSet, uuid)
+}
+
+// HasFlowProperty returns true if the package contains a flow property data set
+// with the given UUID.
+func (r *ZipReader) HasFlowProperty(uuid string) bool {
+	return r.has(FlowPropertyDataSet, uuid)
+}
+
+// HasUnitGroup returns true if the package contains a unit group data set with
+// the given UUID.
+func (r *ZipReader) HasUnitGroup(uuid string) bool {
+	return r.has(UnitGroupDataSet, uuid)
+}
+
+// HasSource returns true if the package contains a source data set with the
+// given UUID.
+func (r *ZipReader) HasSource(uuid string) bool {
+	return r.has(SourceDataSet, uuid)
+}
+
+// HasContact returns true if the package contains a contact data set with the
+// given UUID.
+func (r *ZipReader) HasContact(uuid string) bool {
+	return r.has(ContactDataSet, uuid)
+}
+
+// FlowPropertyUnit returns the name of the reference unit of the unit group of
+// the flow property with the given UUID, e.g. `kg` for mass. It returns an
+// error that wraps ErrDataSetNotFound if the flow property, its unit group, or
+// the reference unit of that unit group is missing.
+func (r *ZipReader) FlowPropertyUnit(flowPropertyUUID string) (string, error) {
+	fp, err := r.GetFlowProperty(flowPropertyUUID)
+	if err != nil {
+		return "", err
+	}
+	if fp.UnitGroup == nil {
+		return "", fmt.Errorf("%w: unit group of flow property %s",
+			ErrDataSetNotFound, flowPropertyUUID)
+	}
+	ug, err := r.GetUnitGroup(fp.UnitGroup.UUID)
+	if err != nil {
+		return "", err
+	}
+	unit := ug.ReferenceUnit()
+	if unit == nil {
+		return "", fmt.Errorf("%w: reference unit of unit group %s",
+			ErrDataSetNotFound, fp.UnitGroup.UUID)
+	}
+	return unit.Name, nil
+}
+
+// ProcessVersions returns all versions of the process with the given UUID that
+// are contained in the package, sorted by version in descending order. It
+// returns ErrDataSetNotFound if there is no such process in the package.
+func (r *ZipReader) ProcessVersions(uuid string) ([]*Process, error) {
+	files := r.index().findAll(ProcessDataSet, uuid)
+	if len(files) == 0 {
+		return nil, ErrDataSetNotFound
+	}
+	processes := make([]*Process, 0, len(files))
+	for _, file := range files {
+		f := r.file(file)
+		p, err := f.ReadProcess()
+		if err != nil {
+			return nil, entryErr(f.Path(), err)
+		}
+		processes = append(processes, p)
+	}
+	sort.SliceStable(processes, func(i, j int) bool {
+		return CompareVersions(processes[i].Version(), processes[j].Version()) > 0
+	})
+	return processes, nil
+}
@@ -0,0 +1,237 @@
+package ilcd
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetDataSets(t *testing.T) {
+	r := openTestZip(t)
+	p, err := r.GetProcess("c93541fe-0b28-40b8-a890-9948e9f1d41f")
+	if err != nil || p.UUID() != "c93541fe-0b28-40b8-a890-9948e9f1d41f" {
+		t.Fatal("failed to get process", err)
+	}
+	f, err := r.GetFlow("fe0acd60-3ddc-11dd-aaa4-0050c2490048")
+	if err != nil || f.UUID() != "fe0acd60-3ddc-11dd-aaa4-0050c2490048" {
+		t.Fatal("failed to get flow", err)
+	}
+	fp, err := r.GetFlowProperty("93a60a56-a3c8-11da-a746-0800200b9a66")
+	if err != nil || fp.Name("en") != "Mass" {
+		t.Fatal("failed to get flow property", err)
+	}
+	ug, err := r.GetUnitGroup("ad38d542-3fe9-439d-9b95-2f5f7752acaf")
+	if err != nil || ug.ReferenceUnit().Name != "kg" {
+		t.Fatal("failed to get unit group", err)
+	}
+	s, err := r.GetSource("220580af-2c84-4e60-82ed-c30a1c6f63f5")
+	if err != nil || s.Info.Citation != "GaBi database" {
+		t.Fatal("failed to get source", err)
+	}
+	c, err := r.GetContact("97f476bd-415a-4463-955a-019202b70ae4")
+	if err != nil || c.Info.ShortName.Get("en") != "JRC" {
+		t.Fatal("failed to get contact", err)
+	}
+	m, err := r.GetMethod("992c8e8d-769a-4930-9b0f-4fa323250738")
+	if err != nil || m.UUID() != "992c8e8d-769a-4930-9b0f-4fa323250738" {
+		t.Fatal("failed to get method", err)
+	}
+}
+
+func TestGetDataSetNotFound(t *testing.T) {
+	r := openTestZip(t)
+	if _, err := r.GetFlow("c93541fe-0b28-40b8-a890-9948e9f1d41f"); err != ErrDataSetNotFound {
+		t.Fatal("expected ErrDataSetNotFound, got", err)
+	}
+	if _, err := r.GetContactData("c93541fe-0b28-40b8-a890-9948e9f1d41f"); err != ErrDataSetNotFound {
+		t.Fatal("expected ErrDataSetNotFound, got", err)
+	}
+	if ug, err := r.GetUnitGroup("c93541fe-0b28-40b8-a890-9948e9f1d41f"); err != ErrDataSetNotFound ||
+		ug.ReferenceUnit() != nil {
+		t.Fatal("expected ErrDataSetNotFound, got", err)
+	}
+	if s, err := r.GetSource("c93541fe-0b28-40b8-a890-9948e9f1d41f"); err != ErrDataSetNotFound || s != nil {
+		t.Fatal("expected ErrDataSetNotFound, got", err)
+	}
+	if c, err := r.GetContact("c93541fe-0b28-40b8-a890-9948e9f1d41f"); err != ErrDataSetNotFound || c != nil {
+		t.Fatal("expected ErrDataSetNotFound, got", err)
+	}
+}
+
+func TestGetMethodFolders(t *testing.T) {
+	data, err := ioutil.ReadFile("sample_data/method.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	uuid := "992c8e8d-769a-4930-9b0f-4fa323250738"
+	for _, folder := range []string{"lciamethods", "LCIAMethod", "methods"} {
+		path := filepath.Join(t.TempDir(), "methods.zip")
+		err := WriteZip(path, map[string][]byte{
+			"ILCD/" + folder + "/" + uuid + ".xml": data,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := NewZipReader(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, err := r.GetMethod(uuid)
+		if err != nil || m.UUID() != uuid {
+			t.Fatal("failed to get method from folder", folder, err)
+		}
+		if _, err := r.GetMethodData("c93541fe-0b28-40b8-a890-9948e9f1d41f"); err != ErrDataSetNotFound {
+			t.Fatal("expected ErrDataSetNotFound, got", err)
+		}
+		if _, err := r.GetMethod("c93541fe-0b28-40b8-a890-9948e9f1d41f"); err != ErrDataSetNotFound {
+			t.Fatal("expected ErrDataSetNotFound, got", err)
+		}
+		r.Close()
+	}
+}
+
+func TestGetDataVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "versions.zip")
+	w, err := NewZipWriter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	uuid := "c93541fe-0b28-40b8-a890-9948e9f1d41f"
+	for _, version := range []string{"00.02.000", "01.00.000", "00.10.000"} {
+		p := NewProcess(uuid).SetVersion(version).Build()
+		if err := w.WriteDataSet(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	p, err := r.GetProcess(uuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Version() != "01.00.000" {
+		t.Fatal("expected the latest version, got", p.Version())
+	}
+	data, err := r.GetProcessDataVersion(uuid, "0.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, _ = ReadProcess(data); p.Version() != "00.10.000" {
+		t.Fatal("expected version 00.10.000, got", p.Version())
+	}
+	if _, err := r.GetProcessDataVersion(uuid, "02.00.000"); !errors.Is(err, ErrDataSetNotFound) {
+		t.Fatal("expected ErrDataSetNotFound, got", err)
+	}
+
+	versions, err := r.ProcessVersions(uuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 3 || versions[0].Version() != "01.00.000" ||
+		versions[1].Version() != "00.10.000" || versions[2].Version() != "00.02.000" {
+		t.Fatal("unexpected process versions", versions)
+	}
+	if _, err := r.ProcessVersions("fe0acd60-3ddc-11dd-aaa4-0050c2490048"); !errors.Is(err, ErrDataSetNotFound) {
+		t.Fatal("expected ErrDataSetNotFound, got", err)
+	}
+}
+
+func TestGetExternalDoc(t *testing.T) {
+	r := openTestZip(t)
+	for _, name := range []string{"blank.JPG", "ILCD/external_docs/blank.JPG"} {
+		data, err := r.GetExternalDoc(name)
+		if err != nil || string(data) != "no image" {
+			t.Fatal("failed to get external doc", name, err)
+		}
+	}
+	dest := filepath.Join(t.TempDir(), "docs", "blank.jpg")
+	if err := r.GetExternalDocFile("blank.JPG", dest); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(dest)
+	if err != nil || string(data) != "no image" {
+		t.Fatal("failed to write external doc", err)
+	}
+	if _, err := r.GetExternalDoc("missing.pdf"); !errors.Is(err, ErrEntryNotFound) {
+		t.Fatal("expected ErrEntryNotFound, got", err)
+	}
+	if err := r.GetExternalDocFile("missing.pdf", dest); !errors.Is(err, ErrEntryNotFound) {
+		t.Fatal("expected ErrEntryNotFound, got", err)
+	}
+}
+
+func TestResolveReferenceFlowProperty(t *testing.T) {
+	r := openTestZip(t)
+	fp, err := r.ResolveReferenceFlowProperty("fe0acd60-3ddc-11dd-aaa4-0050c2490048")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fp.UUID() != "93a60a56-a3c8-11da-a746-0800200b9a66" {
+		t.Fatal("unexpected flow property", fp.UUID())
+	}
+	_, err = r.ResolveReferenceFlowProperty("08a91e70-3ddc-11dd-923d-0050c2490048")
+	if !errors.Is(err, ErrDataSetNotFound) {
+		t.Fatal("expected ErrDataSetNotFound, got", err)
+	}
+}
+
+func TestHasDataSets(t *testing.T) {
+	r := openTestZip(t)
+	if !r.HasProcess("c93541fe-0b28-40b8-a890-9948e9f1d41f") ||
+		!r.HasFlow("FE0ACD60-3DDC-11DD-AAA4-0050C2490048") ||
+		!r.HasFlowProperty("93a60a56-a3c8-11da-a746-0800200b9a66") ||
+		!r.HasUnitGroup("ad38d542-3fe9-439d-9b95-2f5f7752acaf") ||
+		!r.HasSource("220580af-2c84-4e60-82ed-c30a1c6f63f5") ||
+		!r.HasContact("97f476bd-415a-4463-955a-019202b70ae4") ||
+		!r.HasMethod("992c8e8d-769a-4930-9b0f-4fa323250738") {
+		t.Fatal("expected data sets not found")
+	}
+	if r.HasModel("c93541fe-0b28-40b8-a890-9948e9f1d41f") ||
+		r.HasFlow("c93541fe-0b28-40b8-a890-9948e9f1d41f") {
+		t.Fatal("found data sets with the wrong type")
+	}
+}
+
+func TestFlowPropertyUnit(t *testing.T) {
+	fp, err := ioutil.ReadFile("sample_data/flowprop.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ug, err := ioutil.ReadFile("sample_data/unitgroup.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "units.zip")
+	err = WriteZip(path, map[string][]byte{
+		"ILCD/flowproperties/93a60a56-a3c8-11da-a746-0800200b9a66.xml": bytes.ReplaceAll(fp,
+			[]byte("93a60a57-a4c8-11da-a746-0800200c9a66"), []byte("ad38d542-3fe9-439d-9b95-2f5f7752acaf")),
+		"ILCD/unitgroups/ad38d542-3fe9-439d-9b95-2f5f7752acaf.xml": ug,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	unit, err := r.FlowPropertyUnit("93a60a56-a3c8-11da-a746-0800200b9a66")
+	if err != nil || unit != "kg" {
+		t.Fatal("expected kg, got", unit, err)
+	}
+
+	r = openTestZip(t)
+	_, err = r.FlowPropertyUnit("93a60a56-a3c8-11da-a746-0800200b9a66")
+	if !errors.Is(err, ErrDataSetNotFound) {
+		t.Fatal("expected ErrDataSetNotFound, got", err)
+	}
+}
@@ -0,0 +1,289 @@
+package ilcd
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+)
+
+// zipIndex maps the UUIDs of the data sets in a package to the zip entries
+// that contain them. The index is derived from the entry names only.
+type zipIndex struct {
+	Entries map[string][]indexedEntry `json:"entries"`
+	files   map[string]*zip.File
+}
+
+// indexedEntry describes a data set entry in the index.
+type indexedEntry struct {
+	Name    string      `json:"name"`
+	Type    DataSetType `json:"type"`
+	Version string      `json:"version,omitempty"`
+}
+
+func newZipIndex(files []*zip.File) *zipIndex {
+	idx := &zipIndex{
+		Entries: make(map[string][]indexedEntry),
+		files:   make(map[string]*zip.File, len(files)),
+	}
+	for _, f := range files {
+		idx.files[f.Name] = f
+		dsType := newZipFile(f).Type()
+		if newDataSet(dsType) == nil {
+			continue
+		}
+		uuid, version := ParseEntryName(f.Name)
+		if uuid == "" {
+			continue
+		}
+		key := strings.ToLower(uuid)
+		idx.Entries[key] = append(idx.Entries[key], indexedEntry{
+			Name:    f.Name,
+			Type:    dsType,
+			Version: version,
+		})
+	}
+	return idx
+}
+
+// find returns the zip entry of the given type and UUID with the latest
+// version or nil if there is no such entry. The versions are taken from the
+// entry names. If there are multiple entries with the same version, the first
+// of them is returned.
+func (idx *zipIndex) find(dsType DataSetType, uuid string) *zip.File {
+	var latest *indexedEntry
+	entries := idx.Entries[strings.ToLower(uuid)]
+	for i := range entries {
+		e := &entries[i]
+		if e.Type != dsType {
+			continue
+		}
+		if latest == nil || CompareVersions(e.Version, latest.Version) > 0 {
+			latest = e
+		}
+	}
+	if latest == nil {
+		return nil
+	}
+	return idx.files[latest.Name]
+}
+
+// findAll returns all zip entries of the given type and UUID.
+func (idx *zipIndex) findAll(dsType DataSetType, uuid string) []*zip.File {
+	var files []*zip.File
+	for _, e := range idx.Entries[strings.ToLower(uuid)] {
+		if e.Type == dsType {
+			files = append(files, idx.files[e.Name])
+		}
+	}
+	return files
+}
+
+// findVersion returns the zip entry of the given type, UUID, and version or
+// nil if there is no such entry.
+func (idx *zipIndex) findVersion(dsType DataSetType, uuid, version string) *zip.File {
+	for _, e := range idx.Entries[strings.ToLower(uuid)] {
+		if e.Type == dsType && CompareVersions(e.Version, version) == 0 {
+			return idx.files[e.Name]
+		}
+	}
+	return nil
+}
+
+// index returns the index of the package; it is created when it is requested
+// for the first time. It is safe to call index concurrently.
+func (r *ZipReader) index() *zipIndex {
+	r.idxMu.Lock()
+	defer r.idxMu.Unlock()
+	if r.idx == nil {
+		r.idx = newZipIndex(r.r.File)
+	}
+	return r.idx
+}
+
+// SaveIndex writes the index of the package, which maps the UUIDs of the data
+// sets to their entries in the package, as JSON to the given file. This index
+// can be loaded with LoadIndex so that it does not need to be rebuilt when the
+// same package is opened again.
+func (r *ZipReader) SaveIndex(path string) error {
+	data, err := json.Marshal(r.index())
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// LoadIndex loads an index that was saved with SaveIndex from the given file.
+// It returns an error if the index contains entries that are not in the
+// package, e.g. when the index was created for another package.
+func (r *ZipReader) LoadIndex(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	idx := &zipIndex{}
+	if err := json.Unmarshal(data, idx); err != nil {
+		return err
+	}
+	idx.files = make(map[string]*zip.File, len(r.r.File))
+	for _, f := range r.r.File {
+		idx.files[f.Name] = f
+	}
+	for _, entries := range idx.Entries {
+		for _, e := range entries {
+			if idx.files[e.Name] == nil {
+				return fmt.Errorf("index does not match package: no entry %s", e.Name)
+			}
+		}
+	}
+	if idx.Entries == nil {
+		idx.Entries = make(map[string][]indexedEntry)
+	}
+	r.idxMu.Lock()
+	r.idx = idx
+	r.idxMu.Unlock()
+	return nil
+}
+
+// IndexEntry describes a data set of a package as listed in the catalog of the
+// package.
+type IndexEntry struct {
+	Type    DataSetType
+	UUID    string
+	Version string
+	Path    string
+}
+
+// IndexEntries returns the catalog of the data sets in the package. If the
+// package contains an `index.xml` file in its root or `ILCD` folder, the data
+// set references in that file are returned; the paths of the entries are
+// resolved from the references or, if the references have no URI, looked up
+// in the package. Otherwise, the catalog is derived from the entry names of
+// the package, sorted by path.
+func (r *ZipReader) IndexEntries() ([]IndexEntry, error) {
+	for _, f := range r.r.File {
+		if !isIndexFile(f.Name) {
+			continue
+		}
+		data, err := r.file(f).Read()
+		if err != nil {
+			return nil, entryErr(f.Name, err)
+		}
+		refs, err := findRefs(data)
+		if err != nil {
+			return nil, entryErr(f.Name, err)
+		}
+		entries := make([]IndexEntry, 0, len(refs))
+		for i := range refs {
+			ref := &refs[i]
+			e := IndexEntry{
+				Type:    ref.DataSetType(),
+				UUID:    ref.UUID,
+				Version: ref.Version,
+				Path:    ref.ResolvedURI(f.Name),
+			}
+			if e.Path == "" {
+				if file := r.index().find(e.Type, e.UUID); file != nil {
+					e.Path = file.Name
+				}
+			}
+			entries = append(entries, e)
+		}
+		return entries, nil
+	}
+
+	return r.scannedEntries(), nil
+}
+
+// scannedEntries returns the data set entries of the package that are derived
+// from the entry names, sorted by path.
+func (r *ZipReader) scannedEntries() []IndexEntry {
+	var entries []IndexEntry
+	for uuid, indexed := range r.index().Entries {
+		for _, e := range indexed {
+			entries = append(entries, IndexEntry{
+				Type:    e.Type,
+				UUID:    uuid,
+				Version: e.Version,
+				Path:    e.Name,
+			})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Path < entries[j].Path
+	})
+	return entries
+}
+
+// PackageIndex lists the data sets of a package grouped by their types.
+type PackageIndex struct {
+	entries map[DataSetType][]IndexEntry
+}
+
+// Index returns an index of the data sets in the package. Unlike IndexEntries,
+// the index is always derived from the entry names of the package, so that no
+// data set, and also no `index.xml` file, needs to be parsed. The path of an
+// entry can be used to read the raw data of the data set later, e.g. via
+// `fs.ReadFile(r.FS(), path)`.
+func (r *ZipReader) Index() (*PackageIndex, error) {
+	idx := &PackageIndex{entries: make(map[DataSetType][]IndexEntry)}
+	for _, e := range r.scannedEntries() {
+		idx.entries[e.Type] = append(idx.entries[e.Type], e)
+	}
+	return idx, nil
+}
+
+// Entries returns the entries of the data sets of the given type, sorted by
+// path.
+func (idx *PackageIndex) Entries(dsType DataSetType) []IndexEntry {
+	if idx == nil {
+		return nil
+	}
+	return idx.entries[dsType]
+}
+
+// UUIDs returns the UUIDs of the data sets of the given type, sorted by the
+// paths of their entries. If the package contains multiple versions of a data
+// set, its UUID is contained only once.
+func (idx *PackageIndex) UUIDs(dsType DataSetType) []string {
+	var uuids []string
+	seen := make(map[string]bool)
+	for _, e := range idx.Entries(dsType) {
+		if !seen[e.UUID] {
+			seen[e.UUID] = true
+			uuids = append(uuids, e.UUID)
+		}
+	}
+	return uuids
+}
+
+// Counts returns the number of entries in the package per data set type. The
+// types are inferred from the entry names, so that this is fast even for large
+// packages as no data set is parsed. Directory entries are not counted, and
+// types without entries are not contained in the map.
+func (r *ZipReader) Counts() map[DataSetType]int {
+	counts := make(map[DataSetType]int)
+	for _, f := range r.r.File {
+		if strings.HasSuffix(f.Name, "/") {
+			continue
+		}
+		counts[newZipFile(f).Type()]++
+	}
+	return counts
+}
+
+// Count returns the number of entries of the given data set type in the
+// package; see Counts.
+func (r *ZipReader) Count(dsType DataSetType) int {
+	return r.Counts()[dsType]
+}
+
+// isIndexFile returns true if the given entry name is the name of a package
+// catalog: an `index.xml` file in the root or `ILCD` folder of the package.
+func isIndexFile(name string) bool {
+	dir, file := path.Split(strings.ToLower(name))
+	return file == "index.xml" && (dir == "" || dir == "ilcd/")
+}
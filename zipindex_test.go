@@ -0,0 +1,166 @@
+package ilcd
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestFindDataSet(t *testing.T) {
+	r := openTestZip(t)
+	f := r.FindDataSet(FlowDataSet, "fe0acd60-3ddc-11dd-aaa4-0050c2490048")
+	if f == nil || f.Type() != FlowDataSet {
+		t.Fatal("could not find flow")
+	}
+	if r.FindDataSet(FlowDataSet, "FE0ACD60-3DDC-11DD-AAA4-0050C2490048") == nil {
+		t.Fatal("UUIDs should be case insensitive")
+	}
+	if r.FindDataSet(ProcessDataSet, "fe0acd60-3ddc-11dd-aaa4-0050c2490048") != nil {
+		t.Fatal("the flow is not a process")
+	}
+}
+
+func TestConcurrentLookups(t *testing.T) {
+	r := openTestZip(t)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if !r.HasFlow("fe0acd60-3ddc-11dd-aaa4-0050c2490048") {
+				t.Error("could not find flow")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSaveLoadIndex(t *testing.T) {
+	r := openTestZip(t)
+	path := filepath.Join(t.TempDir(), "index.json")
+	if err := r.SaveIndex(path); err != nil {
+		t.Fatal(err)
+	}
+	other := openTestZip(t)
+	if err := other.LoadIndex(path); err != nil {
+		t.Fatal(err)
+	}
+	f := other.FindDataSet(ProcessDataSet, "c93541fe-0b28-40b8-a890-9948e9f1d41f")
+	if f == nil {
+		t.Fatal("could not find process with loaded index")
+	}
+	if e := other.idx.Entries["c93541fe-0b28-40b8-a890-9948e9f1d41f"]; len(e) != 1 || e[0].Version != "00.00.000" {
+		t.Fatal("the version should be stored in the index")
+	}
+
+	otherPath := filepath.Join(t.TempDir(), "other.zip")
+	w, _ := NewZipWriter(otherPath)
+	w.Write("ILCD/flows/other.xml", []byte("<flowDataSet/>"))
+	w.Close()
+	empty, err := NewZipReader(otherPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer empty.Close()
+	if err := empty.LoadIndex(path); err == nil {
+		t.Fatal("loading the index of another package should fail")
+	}
+}
+
+func TestIndexEntries(t *testing.T) {
+	r := openTestZip(t)
+	entries, err := r.IndexEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 7 {
+		t.Fatal("expected 7 entries from the file scan, got", len(entries))
+	}
+	for i, e := range entries {
+		if i > 0 && entries[i-1].Path > e.Path {
+			t.Fatal("entries are not sorted by path")
+		}
+		if e.UUID == "" || r.FindDataSet(e.Type, e.UUID) == nil {
+			t.Fatal("invalid index entry", e)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "catalog.zip")
+	uuid := "fe0acd60-3ddc-11dd-aaa4-0050c2490048"
+	err = WriteZip(path, map[string][]byte{
+		"ILCD/flows/" + uuid + ".xml": mustRead(t, "sample_data/flow.xml"),
+		"ILCD/index.xml": []byte(`<index>
+  <referenceToDataSet type="flow data set" refObjectId="` + uuid + `" version="01.00.000"/>
+  <referenceToDataSet type="process data set" refObjectId="c93541fe-0b28-40b8-a890-9948e9f1d41f"
+    version="00.00.000" uri="processes/c93541fe-0b28-40b8-a890-9948e9f1d41f.xml"/>
+</index>`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cr, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cr.Close()
+	entries, err = cr.IndexEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatal("expected 2 entries from the catalog, got", len(entries))
+	}
+	if e := entries[0]; e.Type != FlowDataSet || e.Version != "01.00.000" ||
+		e.Path != "ILCD/flows/"+uuid+".xml" {
+		t.Fatal("unexpected flow entry", e)
+	}
+	if e := entries[1]; e.Type != ProcessDataSet ||
+		e.Path != "ILCD/processes/c93541fe-0b28-40b8-a890-9948e9f1d41f.xml" {
+		t.Fatal("unexpected process entry", e)
+	}
+}
+
+func TestPackageIndex(t *testing.T) {
+	r := openTestZip(t)
+	idx, err := r.Index()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, dsType := range []DataSetType{ProcessDataSet, MethodDataSet, FlowDataSet,
+		FlowPropertyDataSet, UnitGroupDataSet, SourceDataSet, ContactDataSet} {
+		entries := idx.Entries(dsType)
+		if len(entries) != 1 || entries[0].Type != dsType {
+			t.Fatal("expected one entry for", dsType, entries)
+		}
+		data, err := fs.ReadFile(r.FS(), entries[0].Path)
+		if err != nil || len(data) == 0 {
+			t.Fatal("failed to read entry", entries[0].Path, err)
+		}
+	}
+	uuids := idx.UUIDs(FlowDataSet)
+	if len(uuids) != 1 || uuids[0] != "fe0acd60-3ddc-11dd-aaa4-0050c2490048" {
+		t.Fatal("unexpected flow UUIDs", uuids)
+	}
+	if len(idx.Entries(ModelDataSet)) != 0 || len(idx.Entries(ExternalDoc)) != 0 {
+		t.Fatal("only data sets should be indexed")
+	}
+	var empty *PackageIndex
+	if empty.Entries(FlowDataSet) != nil || empty.UUIDs(FlowDataSet) != nil {
+		t.Fatal("nil index should have no entries")
+	}
+}
+
+func TestCounts(t *testing.T) {
+	r := openTestZip(t)
+	counts := r.Counts()
+	for _, dsType := range []DataSetType{ProcessDataSet, MethodDataSet, FlowDataSet,
+		FlowPropertyDataSet, UnitGroupDataSet, SourceDataSet, ContactDataSet, ExternalDoc} {
+		if counts[dsType] != 1 || r.Count(dsType) != 1 {
+			t.Fatal("expected one entry of type", dsType, counts)
+		}
+	}
+	if _, ok := counts[ModelDataSet]; ok || r.Count(ModelDataSet) != 0 {
+		t.Fatal("there should be no models in the package")
+	}
+}
@@ -0,0 +1,623 @@
+package ilcd
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// createTestZip writes the sample data sets into a new package in a temporary
+// folder and returns the path to that package.
+func createTestZip(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.zip")
+	w, err := NewZipWriter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := []string{
+		"sample_data/contact.xml",
+		"sample_data/flow.xml",
+		"sample_data/flowprop.xml",
+		"sample_data/method.xml",
+		"sample_data/process.xml",
+		"sample_data/source.xml",
+		"sample_data/unitgroup.xml",
+	}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ds := testDataSet(t, file)
+		if err := w.Write(w.Path(ds), data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Write("ILCD/external_docs/blank.JPG", []byte("no image")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// testDataSet reads the sample data set from the given file.
+func testDataSet(t *testing.T, file string) DataSet {
+	t.Helper()
+	var ds DataSet
+	var err error
+	switch filepath.Base(file) {
+	case "contact.xml":
+		ds, err = ReadContactFile(file)
+	case "flow.xml":
+		ds, err = ReadFlowFile(file)
+	case "flowprop.xml":
+		ds, err = ReadFlowPropertyFile(file)
+	case "method.xml":
+		ds, err = ReadMethodFile(file)
+	case "process.xml":
+		ds, err = ReadProcessFile(file)
+	case "source.xml":
+		ds, err = ReadSourceFile(file)
+	case "unitgroup.xml":
+		ds, err = ReadUnitGroupFile(file)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ds
+}
+
+// openTestZip opens a reader on a new test package that is closed when the
+// test finishes.
+func openTestZip(t *testing.T) *ZipReader {
+	t.Helper()
+	r, err := NewZipReader(createTestZip(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { r.Close() })
+	return r
+}
+
+func TestNewZipReaderFromBytes(t *testing.T) {
+	data, err := ioutil.ReadFile(createTestZip(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewZipReaderFromBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flow, err := r.GetFlow("fe0acd60-3ddc-11dd-aaa4-0050c2490048")
+	if err != nil || flow.UUID() != "fe0acd60-3ddc-11dd-aaa4-0050c2490048" {
+		t.Fatal("failed to read flow from memory", err)
+	}
+	if err := r.Close(); err != nil || !r.Closed() {
+		t.Fatal("failed to close reader", err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal("closing the reader twice should not fail:", err)
+	}
+
+	r, err = NewZipReaderFromBytes([]byte("not a zip file"))
+	if r != nil || !errors.Is(err, zip.ErrFormat) {
+		t.Fatal("expected zip.ErrFormat, got", err)
+	}
+}
+
+func TestNewZipReaderAt(t *testing.T) {
+	file, err := os.Open(createTestZip(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewZipReaderAt(file, info.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	count := 0
+	r.EachFile(func(f *ZipFile) bool {
+		count++
+		return true
+	})
+	if count != 8 {
+		t.Fatal("expected 8 entries, got", count)
+	}
+	if _, err := r.GetProcess("c93541fe-0b28-40b8-a890-9948e9f1d41f"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the underlying file is still open after closing the reader
+	if _, err := file.ReadAt(make([]byte, 4), 0); err != nil {
+		t.Fatal("the file should not be closed:", err)
+	}
+}
+
+func TestCloseTwice(t *testing.T) {
+	r, err := NewZipReader(createTestZip(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Closed() {
+		t.Fatal("the reader should be open")
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !r.Closed() {
+		t.Fatal("the reader should be closed")
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal("closing the reader twice should not fail:", err)
+	}
+}
+
+func TestOpenMissingZip(t *testing.T) {
+	r, err := NewZipReader(filepath.Join(t.TempDir(), "missing.zip"))
+	if r != nil {
+		t.Fatal("the reader should be nil when the package cannot be opened")
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatal("the error should wrap the cause, got", err)
+	}
+}
+
+func TestOpenCorruptZip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "corrupt.zip")
+	if err := ioutil.WriteFile(path, []byte("not a zip file"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewZipReader(path)
+	if r != nil || !errors.Is(err, zip.ErrFormat) {
+		t.Fatal("expected a nil reader and zip.ErrFormat, got", err)
+	}
+	r, err = NewEncryptedZipReader(path, "secret")
+	if r != nil || !errors.Is(err, zip.ErrFormat) {
+		t.Fatal("expected a nil reader and zip.ErrFormat, got", err)
+	}
+}
+
+func TestEntryInfo(t *testing.T) {
+	r := openTestZip(t)
+	info, err := r.EntryInfo("ILCD/external_docs/blank.JPG")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.UncompressedSize != 8 || info.CRC32 == 0 || info.CompressedSize == 0 {
+		t.Fatal("wrong entry info", info)
+	}
+	if _, err := r.EntryInfo("ILCD/unknown.xml"); err != ErrEntryNotFound {
+		t.Fatal("expected ErrEntryNotFound for unknown entries")
+	}
+}
+
+func TestCheckIntegrity(t *testing.T) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range []string{"ILCD/flows/ok.xml", "ILCD/flows/broken.xml"} {
+		f, err := w.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store})
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Write([]byte("content of " + name))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Replace(buf.Bytes(),
+		[]byte("content of ILCD/flows/broken.xml"),
+		[]byte("CONTENT OF ILCD/FLOWS/BROKEN.XML"), 1)
+	path := filepath.Join(t.TempDir(), "broken.zip")
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	broken, encrypted := r.CheckIntegrity()
+	if len(broken) != 1 || !errors.Is(broken["ILCD/flows/broken.xml"], zip.ErrChecksum) {
+		t.Fatal("expected exactly one broken entry, got", broken)
+	}
+	if len(encrypted) != 0 {
+		t.Fatal("there are no encrypted entries, got", encrypted)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	r := openTestZip(t)
+	path := filepath.Join(t.TempDir(), "flows.zip")
+	w, err := NewZipWriter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.Transform(w, func(name string, data []byte) (string, []byte, bool, error) {
+		if !IsFlowPath(name) {
+			return "", nil, false, nil
+		}
+		return "flows/" + filepath.Base(name), data, true, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	result, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer result.Close()
+	var names []string
+	result.EachFile(func(f *ZipFile) bool {
+		names = append(names, f.Path())
+		return true
+	})
+	if len(names) != 1 || names[0] != "flows/fe0acd60-3ddc-11dd-aaa4-0050c2490048_03.00.000.xml" {
+		t.Fatal("unexpected entries after transformation:", names)
+	}
+}
+
+func TestTransformEmptyData(t *testing.T) {
+	r := openTestZip(t)
+	path := filepath.Join(t.TempDir(), "empty.zip")
+	w, err := NewZipWriter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.Transform(w, func(name string, data []byte) (string, []byte, bool, error) {
+		if !IsFlowPath(name) {
+			return "", nil, false, nil
+		}
+		return "", nil, true, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	result, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer result.Close()
+	f := result.FindDataSet(FlowDataSet, "fe0acd60-3ddc-11dd-aaa4-0050c2490048")
+	if f == nil {
+		t.Fatal("a kept entry with empty data should be written")
+	}
+	if data, err := f.Read(); err != nil || len(data) != 0 {
+		t.Fatal("expected an empty entry, got", len(data), err)
+	}
+}
+
+func TestEachFlowData(t *testing.T) {
+	r := openTestZip(t)
+	count := 0
+	err := r.EachFlowData(func(uuid string, data []byte) bool {
+		count++
+		if uuid != "fe0acd60-3ddc-11dd-aaa4-0050c2490048" {
+			t.Fatal("wrong flow UUID", uuid)
+		}
+		flow, err := ReadFlow(data)
+		if err != nil || flow.UUID() != uuid {
+			t.Fatal("data do not contain the flow")
+		}
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatal("expected exactly one flow")
+	}
+}
+
+func TestEachMethodData(t *testing.T) {
+	r := openTestZip(t)
+	count := 0
+	err := r.EachMethodData(func(uuid string, data []byte) bool {
+		count++
+		method, err := ReadMethod(data)
+		if err != nil || method.UUID() != uuid {
+			t.Fatal("data do not contain the method")
+		}
+		raw, err := r.GetMethodData(uuid)
+		if err != nil || !bytes.Equal(raw, data) {
+			t.Fatal("GetMethodData returned different data", err)
+		}
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatal("expected exactly one method")
+	}
+}
+
+func TestEachWhere(t *testing.T) {
+	r := openTestZip(t)
+	var names []string
+	err := r.EachWhere(func(name string) bool {
+		return filepath.Ext(name) == ".JPG"
+	}, func(name string, data []byte) bool {
+		names = append(names, name)
+		if string(data) != "no image" {
+			t.Fatal("wrong data of", name)
+		}
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 1 || names[0] != "ILCD/external_docs/blank.JPG" {
+		t.Fatal("unexpected matches:", names)
+	}
+}
+
+func TestFS(t *testing.T) {
+	r := openTestZip(t)
+	fsys := r.FS()
+	data, err := fs.ReadFile(fsys, "ILCD/external_docs/blank.JPG")
+	if err != nil || string(data) != "no image" {
+		t.Fatal("failed to read file from package file system", err)
+	}
+	count := 0
+	err = fs.WalkDir(fsys, "ILCD", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			count++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 8 {
+		t.Fatal("expected 8 files in the package, got", count)
+	}
+}
+
+func TestEachErrorContainsEntryName(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "invalid.zip")
+	w, _ := NewZipWriter(path)
+	w.Write("ILCD/flows/invalid.xml", []byte("<flowDataSet>"))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	err = r.EachFlow(func(*Flow) bool { return true })
+	if err == nil || !strings.Contains(err.Error(), `entry "ILCD/flows/invalid.xml"`) {
+		t.Fatal("the error should contain the entry name, got", err)
+	}
+}
+
+func TestEachDataSetFileAndExternalDoc(t *testing.T) {
+	r := openTestZip(t)
+	dataSets := 0
+	r.EachDataSetFile(func(f *ZipFile) bool {
+		if IsExternalDoc(f.Path()) {
+			t.Fatal("unexpected external doc", f.Path())
+		}
+		dataSets++
+		return true
+	})
+	if dataSets != 7 {
+		t.Fatal("expected 7 data sets, got", dataSets)
+	}
+	var docs []string
+	r.EachExternalDoc(func(f *ZipFile) bool {
+		docs = append(docs, f.Path())
+		return true
+	})
+	if len(docs) != 1 || docs[0] != "ILCD/external_docs/blank.JPG" {
+		t.Fatal("unexpected external docs", docs)
+	}
+}
+
+func TestOnProgress(t *testing.T) {
+	r := openTestZip(t)
+	var calls, last, total int
+	r.OnProgress(func(p, n int) {
+		calls++
+		if p != last+1 {
+			t.Fatal("unexpected progress", p, "after", last)
+		}
+		last, total = p, n
+	})
+	if err := r.EachProcess(func(p *Process) bool { return true }); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 8 || last != 8 || total != 8 {
+		t.Fatal("unexpected progress calls", calls, last, total)
+	}
+	r.OnProgress(nil)
+	r.EachFile(func(f *ZipFile) bool { return true })
+	if calls != 8 {
+		t.Fatal("progress function was not removed")
+	}
+}
+
+func TestOpenSplitArchive(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"db.zip":    "not a zip file",
+		"db.z01":    "not a zip file",
+		"vol.z02":   "not a zip file",
+		"sig.zip":   "PK\x07\x08not a zip file",
+		"plain.zip": "not a zip file",
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"db.zip", "vol.z02", "sig.zip"} {
+		_, err := NewZipReader(filepath.Join(dir, name))
+		if !errors.Is(err, ErrSplitArchive) {
+			t.Fatal("expected ErrSplitArchive for", name, "got", err)
+		}
+	}
+	_, err := NewZipReader(filepath.Join(dir, "plain.zip"))
+	if errors.Is(err, ErrSplitArchive) || !errors.Is(err, zip.ErrFormat) {
+		t.Fatal("expected zip.ErrFormat, got", err)
+	}
+}
+
+func TestOpenLenient(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "flows.zip")
+	uuid := "fe0acd60-3ddc-11dd-aaa4-0050c2490048"
+	flow := mustRead(t, "sample_data/flow.xml")
+	if err := WriteZip(path, map[string][]byte{"ILCD/flows/" + uuid + ".xml": flow}); err != nil {
+		t.Fatal(err)
+	}
+	data := mustRead(t, path)
+	// a stub before and more garbage after the archive than the standard
+	// library searches for the end of the central directory
+	data = append([]byte("MZ self-extracting stub"), data...)
+	data = append(data, bytes.Repeat([]byte{0x42}, 100*1024)...)
+	sfx := filepath.Join(dir, "flows.exe")
+	if err := ioutil.WriteFile(sfx, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewZipReader(sfx); !errors.Is(err, zip.ErrFormat) {
+		t.Fatal("expected zip.ErrFormat, got", err)
+	}
+	r, err := NewZipReaderLenient(sfx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	f, err := r.GetFlow(uuid)
+	if err != nil || f.UUID() != uuid {
+		t.Fatal("failed to read flow from lenient reader", err)
+	}
+
+	garbage := filepath.Join(dir, "garbage.zip")
+	if err := ioutil.WriteFile(garbage, []byte("not a zip file"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewZipReaderLenient(garbage); !errors.Is(err, zip.ErrFormat) {
+		t.Fatal("expected zip.ErrFormat, got", err)
+	}
+}
+
+func TestEachFlowFast(t *testing.T) {
+	r := openTestZip(t)
+	count := 0
+	err := r.EachFlowFast(func(f *Flow) bool {
+		count++
+		if f.UUID() != "fe0acd60-3ddc-11dd-aaa4-0050c2490048" || f.FlowType() != ElementaryFlow {
+			t.Fatal("failed to read flow")
+		}
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatal("expected exactly one flow")
+	}
+}
+
+// createFlowZip writes a package with n copies of the sample flow into a
+// temporary folder and returns the path to that package.
+func createFlowZip(b *testing.B, n int) string {
+	b.Helper()
+	data, err := ioutil.ReadFile("sample_data/flow.xml")
+	if err != nil {
+		b.Fatal(err)
+	}
+	entries := make(map[string][]byte, n)
+	for i := 0; i < n; i++ {
+		entries[fmt.Sprintf("ILCD/flows/flow_%d.xml", i)] = data
+	}
+	path := filepath.Join(b.TempDir(), "flows.zip")
+	if err := WriteZip(path, entries); err != nil {
+		b.Fatal(err)
+	}
+	return path
+}
+
+func benchmarkEachFlow(b *testing.B, each func(*ZipReader, func(*Flow) bool) error) {
+	r, err := NewZipReader(createFlowZip(b, 1000))
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer r.Close()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := each(r, func(*Flow) bool { return true }); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEachFlow(b *testing.B) {
+	benchmarkEachFlow(b, func(r *ZipReader, fn func(*Flow) bool) error {
+		return r.EachFlow(fn)
+	})
+}
+
+func BenchmarkEachFlowFast(b *testing.B) {
+	benchmarkEachFlow(b, func(r *ZipReader, fn func(*Flow) bool) error {
+		return r.EachFlowFast(fn)
+	})
+}
+
+func TestEachMethodFolderVariants(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "methods.zip")
+	method := mustRead(t, "sample_data/method.xml")
+	err := WriteZip(path, map[string][]byte{
+		"ILCD/lciamethods/a.xml": method,
+		"ILCD/LCIAMethod/b.xml":  method,
+		"ILCD/methods/c.xml":     method,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	count := 0
+	err = r.EachMethod(func(m *Method) bool {
+		count++
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 {
+		t.Fatal("expected 3 methods, got", count)
+	}
+}
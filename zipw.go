@@ -3,12 +3,15 @@ package ilcd
 import (
 	"archive/zip"
 	"encoding/xml"
+	"io"
 	"os"
+	"sort"
 )
 
 // ZipWriter provides functions to write ILCD zip packages
 type ZipWriter struct {
-	w *zip.Writer
+	w    *zip.Writer
+	file *os.File
 }
 
 // NewZipWriter creates a new ZipWriter.
@@ -17,13 +20,50 @@ func NewZipWriter(filePath string) (*ZipWriter, error) {
 	if err != nil {
 		return nil, err
 	}
-	writer := &ZipWriter{w: zip.NewWriter(file)}
+	writer := &ZipWriter{w: zip.NewWriter(file), file: file}
 	return writer, nil
 }
 
-// Close closes the underlying zip file.
+// NewZipWriterTo creates a new ZipWriter that writes the package to the given
+// writer, e.g. a HTTP response or an in-memory buffer. The writer does not
+// need to be seekable. Note that closing the ZipWriter finishes the package
+// but does not close the given writer.
+func NewZipWriterTo(w io.Writer) *ZipWriter {
+	return &ZipWriter{w: zip.NewWriter(w)}
+}
+
+// WriteZip writes a zip package with the given entries to the given file. The
+// keys of the map are the paths of the entries in the package. The entries are
+// written in the lexical order of their paths.
+func WriteZip(path string, entries map[string][]byte) error {
+	w, err := NewZipWriter(path)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := w.Write(name, entries[name]); err != nil {
+			w.Close()
+			return err
+		}
+	}
+	return w.Close()
+}
+
+// Close finishes the package and closes the underlying zip file.
 func (w *ZipWriter) Close() error {
-	return w.w.Close()
+	err := w.w.Close()
+	if w.file == nil {
+		return err
+	}
+	if ferr := w.file.Close(); err == nil {
+		err = ferr
+	}
+	return err
 }
 
 // Path calculates the path of the zip entry of the given data set.
@@ -58,15 +98,86 @@ func (w *ZipWriter) WriteFile(f *ZipFile) error {
 	return w.Write(f.Path(), data)
 }
 
-// Write writes the given data under the given path into the zip package.
+// PreserveMethod can be passed as compression method to `CopyEntry` to keep
+// the compression method of the original entry.
+const PreserveMethod uint16 = 0xffff
+
+// Write writes the given data under the given path into the zip package. The
+// data are compressed with the `zip.Deflate` method.
 func (w *ZipWriter) Write(path string, data []byte) error {
+	return w.WriteWithMethod(path, data, zip.Deflate)
+}
+
+// WriteWithMethod writes the given data under the given path into the zip
+// package using the given compression method, e.g. `zip.Store` for fast
+// random access or `zip.Deflate` for smaller packages.
+func (w *ZipWriter) WriteWithMethod(path string, data []byte, method uint16) error {
 	if path == "" || len(data) == 0 {
 		return nil
 	}
-	writer, err := w.w.Create(path)
+	writer, err := w.w.CreateHeader(&zip.FileHeader{
+		Name:   path,
+		Method: method,
+	})
 	if err != nil {
 		return err
 	}
 	_, err = writer.Write(data)
 	return err
 }
+
+// Create adds a new entry with the given path to the package and returns a
+// writer for its data. The data are compressed with the `zip.Deflate` method
+// and written to the package as they are written to the returned writer, so
+// that large entries can be written as a stream without holding them in
+// memory. The writer is valid until the next entry is added to the package or
+// the package is closed.
+func (w *ZipWriter) Create(path string) (io.Writer, error) {
+	return w.w.CreateHeader(&zip.FileHeader{
+		Name:   path,
+		Method: zip.Deflate,
+	})
+}
+
+// CopyEntry copies the given zip file into this package under the same path.
+// With `PreserveMethod` or the original compression method of the entry, the
+// raw (compressed) data are copied without decompressing them. Otherwise, the
+// entry is decompressed and written with the given compression method. In
+// both cases, the data are streamed and not read into memory.
+func (w *ZipWriter) CopyEntry(f *ZipFile, method uint16) error {
+	if f == nil {
+		return nil
+	}
+	if method == PreserveMethod || method == f.Method() {
+		return w.copyRaw(f)
+	}
+	r, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	writer, err := w.w.CreateHeader(&zip.FileHeader{
+		Name:   f.Path(),
+		Method: method,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(writer, r)
+	return err
+}
+
+// copyRaw copies the raw data of the given zip file into this package.
+func (w *ZipWriter) copyRaw(f *ZipFile) error {
+	r, err := f.f.OpenRaw()
+	if err != nil {
+		return err
+	}
+	header := f.f.FileHeader
+	writer, err := w.w.CreateRaw(&header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(writer, r)
+	return err
+}
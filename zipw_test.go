@@ -0,0 +1,130 @@
+package ilcd
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"path/filepath"
+	"testing"
+)
+
+func TestZipWriterTo(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewZipWriterTo(&buf)
+	if err := w.Write("ILCD/flows/a.xml", []byte("<flowDataSet/>")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.File) != 1 || r.File[0].Name != "ILCD/flows/a.xml" {
+		t.Fatal("failed to write package to buffer")
+	}
+}
+
+func TestCopyEntry(t *testing.T) {
+	r := openTestZip(t)
+
+	copyAll := func(method uint16) *zip.Reader {
+		var buf bytes.Buffer
+		w := NewZipWriterTo(&buf)
+		r.EachFile(func(f *ZipFile) bool {
+			if err := w.CopyEntry(f, method); err != nil {
+				t.Fatal(err)
+			}
+			return true
+		})
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return zr
+	}
+
+	for _, method := range []uint16{PreserveMethod, zip.Store, zip.Deflate} {
+		zr := copyAll(method)
+		if len(zr.File) != 8 {
+			t.Fatal("expected 8 entries, got", len(zr.File))
+		}
+		for _, zf := range zr.File {
+			f := newZipFile(zf)
+			if method == zip.Store && f.IsCompressed() {
+				t.Fatal("entry should be stored:", f.Path())
+			}
+			if method != zip.Store && f.Method() != zip.Deflate {
+				t.Fatal("entry should be deflated:", f.Path())
+			}
+			if _, err := f.Read(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestWriteZip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixture.zip")
+	err := WriteZip(path, map[string][]byte{
+		"ILCD/processes/b.xml": []byte("<processDataSet/>"),
+		"ILCD/flows/a.xml":     []byte("<flowDataSet/>"),
+		"ILCD/external_docs/c": []byte("c"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewZipReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	var names []string
+	r.EachFile(func(f *ZipFile) bool {
+		names = append(names, f.Path())
+		return true
+	})
+	if len(names) != 3 || names[0] != "ILCD/external_docs/c" ||
+		names[1] != "ILCD/flows/a.xml" || names[2] != "ILCD/processes/b.xml" {
+		t.Fatal("unexpected entries", names)
+	}
+}
+
+func TestStreamEntries(t *testing.T) {
+	r := openTestZip(t)
+	var buf bytes.Buffer
+	w := NewZipWriterTo(&buf)
+	r.EachFile(func(f *ZipFile) bool {
+		src, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer src.Close()
+		dst, err := w.Create("copy/" + f.Path())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.Copy(dst, src); err != nil {
+			t.Fatal(err)
+		}
+		return true
+	})
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	copied, err := NewZipReaderFromBytes(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := copied.GetProcessData("c93541fe-0b28-40b8-a890-9948e9f1d41f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(p, mustRead(t, "sample_data/process.xml")) {
+		t.Fatal("the streamed entry differs from the original")
+	}
+}